@@ -0,0 +1,290 @@
+// Command paygw creates and inspects provider invoices from the command line.
+//
+//	paygw create    -type qpay -uid order-1 -amount 15000
+//	paygw check     -type qpay -uid <bank invoice id> -amount 15000
+//	paygw raw       -type golomt -uid order-1
+//	paygw providers
+//
+// Provider credentials are loaded like sdk.Load: from the optional -config
+// file, overridden by PAYMENTS_* environment variables. Every command accepts
+// -o table (default) or -o json, -timeout and -v to log provider calls with
+// credentials masked.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+const usage = `Usage: paygw <command> [flags]
+
+Commands:
+  create     create an invoice
+  check      check the status of an invoice
+  raw        print the provider's raw check response
+  providers  list the configured providers
+
+Run "paygw <command> -h" for the flags of a command.
+`
+
+// errUsage reports invalid arguments; the flag package has printed why.
+var errUsage = errors.New("usage")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	code := exitCode(err)
+	if code == 1 {
+		fmt.Fprintln(os.Stderr, "paygw:", describe(err))
+	}
+	os.Exit(code)
+}
+
+// exitCode is 0 on success, 2 for invalid arguments and 1 for any other
+// failure.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		return 2
+	default:
+		return 1
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	commands := map[string]func(*options, []string) error{
+		"create":    createCommand,
+		"check":     checkCommand,
+		"raw":       rawCommand,
+		"providers": providersCommand,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		if args[0] != "help" && args[0] != "-h" && args[0] != "--help" {
+			fmt.Fprintf(stderr, "paygw: unknown command %q\n\n", args[0])
+		}
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	opts := &options{name: args[0], stdout: stdout, stderr: stderr}
+	return command(opts, args[1:])
+}
+
+// options are the flags shared by every command.
+type options struct {
+	name           string
+	stdout, stderr io.Writer
+
+	config  string
+	output  string
+	timeout time.Duration
+	verbose bool
+}
+
+// flags returns a flag set for the command with the shared flags registered.
+func (o *options) flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("paygw "+o.name, flag.ContinueOnError)
+	fs.SetOutput(o.stderr)
+	fs.StringVar(&o.config, "config", "", "YAML or JSON provider config file")
+	fs.StringVar(&o.output, "o", "table", "output format: table or json")
+	fs.DurationVar(&o.timeout, "timeout", 60*time.Second, "timeout of the provider call")
+	fs.BoolVar(&o.verbose, "v", false, "log provider requests and responses to stderr")
+	return fs
+}
+
+// parse parses args and checks the shared flags.
+func (o *options) parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(o.stderr, "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	if o.output != "table" && o.output != "json" {
+		fmt.Fprintf(o.stderr, "invalid output format %q: want table or json\n", o.output)
+		return errUsage
+	}
+	return nil
+}
+
+// loadConfig loads the provider config; tests replace it to use fake
+// providers.
+var loadConfig = sdk.Load
+
+// gateway loads the provider config and builds a Gateway over it.
+func (o *options) gateway() (*sdk.Gateway, error) {
+	cfg, err := loadConfig(sdk.LoadOptions{File: o.config})
+	if err != nil {
+		return nil, err
+	}
+	if o.verbose {
+		cfg.Logger = slog.New(slog.NewTextHandler(o.stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	return sdk.NewGateway(cfg)
+}
+
+func (o *options) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), o.timeout)
+}
+
+func createCommand(o *options, args []string) error {
+	fs := o.flags()
+	var (
+		input  types.InvoiceInput
+		typ    string
+		amount string
+	)
+	fs.StringVar(&typ, "type", "", "payment type, e.g. qpay (required)")
+	fs.StringVar(&input.UID, "uid", "", "order uid (required)")
+	fs.StringVar(&amount, "amount", "", "amount in MNT, e.g. 15000 or 15000.50 (required)")
+	fs.StringVar(&input.Phone, "phone", "", "payer phone number (Tokipay, StorePay)")
+	fs.UintVar(&input.CustomerID, "customer-id", 0, "customer id (Balc)")
+	fs.StringVar(&input.Note, "note", "", "invoice description")
+	fs.StringVar(&input.CallbackURL, "callback-url", "", "callback URL (Golomt)")
+	fs.StringVar(&input.ReturnType, "return-type", "", "GET, POST or MOBILE (Golomt)")
+	fs.IntVar(&input.ExpireMinutes, "expire-minutes", 0, "invoice lifetime, 0 uses the provider default")
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	if typ == "" || input.UID == "" || amount == "" {
+		fmt.Fprintln(o.stderr, "-type, -uid and -amount are required")
+		fs.Usage()
+		return errUsage
+	}
+	input.Type = types.PaymentType(typ)
+	var err error
+	if input.Amount, err = types.ParseMoney(amount, types.CurrencyMNT); err != nil {
+		return err
+	}
+
+	gateway, err := o.gateway()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	result, err := gateway.CreateContext(ctx, input)
+	if result == nil {
+		return err
+	}
+	if err != nil {
+		// The invoice was created; show it along with the error.
+		defer fmt.Fprintln(o.stderr, "paygw: warning:", describe(err))
+	}
+	return o.print(result, invoiceTable(result))
+}
+
+func checkCommand(o *options, args []string) error {
+	result, err := o.check(args)
+	if result == nil {
+		return err
+	}
+	if err != nil {
+		defer fmt.Fprintln(o.stderr, "paygw: warning:", describe(err))
+	}
+	return o.print(result, checkTable(result))
+}
+
+func rawCommand(o *options, args []string) error {
+	result, err := o.check(args)
+	if result == nil {
+		return err
+	}
+	if err != nil {
+		defer fmt.Fprintln(o.stderr, "paygw: warning:", describe(err))
+	}
+	// The raw response has no fixed shape, so it is always printed as JSON.
+	return writeJSON(o.stdout, result.Raw)
+}
+
+// check parses the flags of check and raw and checks the invoice.
+func (o *options) check(args []string) (*types.CheckInvoiceResult, error) {
+	fs := o.flags()
+	var (
+		input  types.CheckInvoiceInput
+		typ    string
+		amount string
+	)
+	fs.StringVar(&typ, "type", "", "payment type, e.g. qpay (required)")
+	fs.StringVar(&input.UID, "uid", "", "invoice id as used by the provider, e.g. the QPay bank invoice id (required)")
+	fs.StringVar(&amount, "amount", "", "expected amount in MNT (required for providers that compare it, e.g. qpay)")
+	if err := o.parse(fs, args); err != nil {
+		return nil, err
+	}
+	if typ == "" || input.UID == "" {
+		fmt.Fprintln(o.stderr, "-type and -uid are required")
+		fs.Usage()
+		return nil, errUsage
+	}
+	input.Type = types.PaymentType(typ)
+	if amount != "" {
+		var err error
+		if input.Amount, err = types.ParseMoney(amount, types.CurrencyMNT); err != nil {
+			return nil, err
+		}
+	}
+
+	gateway, err := o.gateway()
+	if err != nil {
+		return nil, err
+	}
+	if amount == "" {
+		// Without it these providers cannot tell whether the invoice is paid.
+		for _, info := range gateway.AvailableProviders() {
+			if info.Type == input.Type && info.CheckRequiresAmount {
+				fmt.Fprintf(o.stderr, "-amount is required to check %s invoices\n", input.Type)
+				fs.Usage()
+				return nil, errUsage
+			}
+		}
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	return gateway.CheckContext(ctx, input)
+}
+
+func providersCommand(o *options, args []string) error {
+	fs := o.flags()
+	if err := o.parse(fs, args); err != nil {
+		return err
+	}
+	gateway, err := o.gateway()
+	if err != nil {
+		return err
+	}
+	providers := gateway.AvailableProviders()
+	return o.print(providers, providersTable(providers))
+}
+
+// describe prefixes err with its SDK error code, so operators can tell a
+// rejected input from a provider outage at a glance.
+func describe(err error) string {
+	var providerErr *types.ProviderError
+	if errors.As(err, &providerErr) {
+		return fmt.Sprintf("[%s] %v", types.ErrorCodeOf(err), err)
+	}
+	return err.Error()
+}
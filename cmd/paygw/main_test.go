@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// useFakeProviders makes the commands load "fake", which compares amounts
+// and reports invoices paid, and "open", which does neither.
+func useFakeProviders(t *testing.T) *fakeprovider.Provider {
+	t.Helper()
+	fake := &fakeprovider.Provider{
+		ProviderInfo: types.ProviderInfo{DisplayName: "Fake", CheckRequiresAmount: true},
+		Answers:      fakeprovider.Statuses(types.PaymentStatusPaid),
+		Raw:          map[string]string{"state": "paid"},
+	}
+	original := loadConfig
+	loadConfig = func(opts sdk.LoadOptions) (sdk.Input, error) {
+		return sdk.Input{Providers: map[types.PaymentType]types.PaymentProvider{
+			"fake": fake,
+			"open": &fakeprovider.Provider{ProviderInfo: types.ProviderInfo{DisplayName: "Open"}},
+		}}, nil
+	}
+	t.Cleanup(func() { loadConfig = original })
+	return fake
+}
+
+func runArgs(args ...string) (stdout, stderr string, code int) {
+	var out, errOut bytes.Buffer
+	err := run(args, &out, &errOut)
+	return out.String(), errOut.String(), exitCode(err)
+}
+
+func TestRunUsage(t *testing.T) {
+	useFakeProviders(t)
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{name: "no command", wantCode: 2, wantStderr: "Usage: paygw"},
+		{name: "unknown command", args: []string{"refund"}, wantCode: 2, wantStderr: `unknown command "refund"`},
+		{name: "help", args: []string{"help"}, wantCode: 2, wantStderr: "Commands:"},
+		{name: "command help", args: []string{"create", "-h"}, wantCode: 2, wantStderr: "-amount"},
+		{name: "unknown flag", args: []string{"providers", "-x"}, wantCode: 2, wantStderr: "flag provided but not defined: -x"},
+		{name: "extra argument", args: []string{"providers", "fake"}, wantCode: 2, wantStderr: "unexpected arguments: [fake]"},
+		{name: "bad output format", args: []string{"providers", "-o", "yaml"}, wantCode: 2, wantStderr: `invalid output format "yaml"`},
+		{name: "create without amount", args: []string{"create", "-type", "fake", "-uid", "order-1"}, wantCode: 2, wantStderr: "-type, -uid and -amount are required"},
+		{name: "check without uid", args: []string{"check", "-type", "fake"}, wantCode: 2, wantStderr: "-type and -uid are required"},
+		{name: "raw without type", args: []string{"raw", "-uid", "order-1"}, wantCode: 2, wantStderr: "-type and -uid are required"},
+		{name: "check needs amount", args: []string{"check", "-type", "fake", "-uid", "inv-1"}, wantCode: 2, wantStderr: "-amount is required to check fake invoices"},
+		{name: "raw needs amount", args: []string{"raw", "-type", "fake", "-uid", "inv-1"}, wantCode: 2, wantStderr: "-amount is required to check fake invoices"},
+		{name: "check without amount", args: []string{"check", "-type", "open", "-uid", "inv-1"}},
+		{name: "malformed amount", args: []string{"create", "-type", "fake", "-uid", "order-1", "-amount", "abc"}, wantCode: 1},
+		{name: "unknown provider", args: []string{"check", "-type", "nope", "-uid", "inv-1"}, wantCode: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := runArgs(tt.args...)
+			if code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d; stderr:\n%s", code, tt.wantCode, stderr)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Fatalf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunCheckAmount(t *testing.T) {
+	fake := useFakeProviders(t)
+	if _, stderr, code := runArgs("check", "-type", "fake", "-uid", "inv-1"); code != 2 || len(fake.Checks()) != 0 {
+		t.Fatalf("check without -amount: exit code %d, %d provider checks; stderr:\n%s", code, len(fake.Checks()), stderr)
+	}
+	if _, stderr, code := runArgs("check", "-type", "fake", "-uid", "inv-1", "-amount", "1500.50"); code != 0 {
+		t.Fatalf("check with -amount: exit code %d; stderr:\n%s", code, stderr)
+	}
+	want := types.CheckInvoiceInput{Type: "fake", UID: "inv-1", Amount: types.NewMoney(150050, types.CurrencyMNT)}
+	if checks := fake.Checks(); len(checks) != 1 || checks[0] != want {
+		t.Fatalf("checks = %+v, want [%+v]", checks, want)
+	}
+}
+
+func TestRunOutput(t *testing.T) {
+	useFakeProviders(t)
+	tests := []struct {
+		name string
+		args []string
+		want string // in table output
+		json any    // decodes the JSON output
+		ok   func(any) bool
+	}{
+		{
+			name: "create",
+			args: []string{"create", "-type", "fake", "-uid", "order-1", "-amount", "15000"},
+			want: "bank_invoice_id  inv-order-1",
+			json: &types.InvoiceResult{},
+			ok:   func(v any) bool { return v.(*types.InvoiceResult).BankInvoiceID == "inv-order-1" },
+		},
+		{
+			name: "check",
+			args: []string{"check", "-type", "fake", "-uid", "inv-1", "-amount", "15000"},
+			want: "status           paid",
+			json: &types.CheckInvoiceResult{},
+			ok: func(v any) bool {
+				result := v.(*types.CheckInvoiceResult)
+				return result.IsPaid && result.PaidAmount == types.MNT(15000)
+			},
+		},
+		{
+			name: "providers",
+			args: []string{"providers"},
+			want: "fake  Fake",
+			json: &[]types.ProviderInfo{},
+			ok:   func(v any) bool { return len(*v.(*[]types.ProviderInfo)) == 2 },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := runArgs(tt.args...)
+			if code != 0 || !strings.Contains(stdout, tt.want) || strings.HasPrefix(stdout, "{") || strings.HasPrefix(stdout, "[") {
+				t.Fatalf("table output (exit code %d):\n%s\nwant a table containing %q; stderr:\n%s", code, stdout, tt.want, stderr)
+			}
+
+			stdout, stderr, code = runArgs(append(tt.args, "-o", "json")...)
+			if code != 0 {
+				t.Fatalf("exit code = %d; stderr:\n%s", code, stderr)
+			}
+			if err := json.Unmarshal([]byte(stdout), tt.json); err != nil || !tt.ok(tt.json) {
+				t.Fatalf("json output = %s (%v)", stdout, err)
+			}
+		})
+	}
+}
+
+func TestRunRaw(t *testing.T) {
+	useFakeProviders(t)
+	// The raw response is JSON even without -o json.
+	stdout, stderr, code := runArgs("raw", "-type", "fake", "-uid", "inv-1", "-amount", "15000")
+	var raw map[string]string
+	if code != 0 || json.Unmarshal([]byte(stdout), &raw) != nil || raw["state"] != "paid" {
+		t.Fatalf("raw = %q (exit code %d), want {\"state\": \"paid\"}; stderr:\n%s", stdout, code, stderr)
+	}
+}
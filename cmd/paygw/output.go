@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// table is a header row followed by data rows.
+type table [][]string
+
+func (o *options) print(v any, t table) error {
+	if o.output == "json" {
+		return writeJSON(o.stdout, v)
+	}
+	return writeTable(o.stdout, t)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeTable(w io.Writer, t table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range t {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func invoiceTable(result *types.InvoiceResult) table {
+	t := table{
+		{"FIELD", "VALUE"},
+		{"bank_invoice_id", result.BankInvoiceID},
+		{"is_paid", fmt.Sprint(result.IsPaid)},
+		{"expires_at", formatTime(result.ExpiresAt)},
+		{"bank_qr_code", result.BankQRCode},
+	}
+	for _, link := range result.Deeplinks {
+		t = append(t, []string{"deeplink " + link.Name, link.Link})
+	}
+	return t
+}
+
+func checkTable(result *types.CheckInvoiceResult) table {
+	return table{
+		{"FIELD", "VALUE"},
+		{"status", string(result.Status)},
+		{"is_paid", fmt.Sprint(result.IsPaid)},
+		{"paid_amount", result.PaidAmount.String()},
+		{"paid_at", formatTime(result.PaidAt)},
+		{"transaction_ids", strings.Join(result.TransactionIDs, ", ")},
+		{"msg", result.Msg},
+	}
+}
+
+func providersTable(providers []types.ProviderInfo) table {
+	t := table{{"TYPE", "NAME", "OPERATIONS", "MIN", "MAX", "REQUIRES", "AVAILABLE"}}
+	for _, info := range providers {
+		operations := make([]string, len(info.Operations))
+		for i, op := range info.Operations {
+			operations[i] = string(op)
+		}
+		var requires []string
+		if info.RequiresPhone {
+			requires = append(requires, "phone")
+		}
+		if info.RequiresCustomerID {
+			requires = append(requires, "customer_id")
+		}
+		if info.RequiresCallbackURL {
+			requires = append(requires, "callback_url")
+		}
+		if info.CheckRequiresAmount {
+			requires = append(requires, "check_amount")
+		}
+		t = append(t, []string{
+			string(info.Type),
+			info.DisplayName,
+			strings.Join(operations, ","),
+			formatLimit(info.MinAmount),
+			formatLimit(info.MaxAmount),
+			orDash(strings.Join(requires, ",")),
+			fmt.Sprint(info.Available),
+		})
+	}
+	return t
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format(time.RFC3339)
+}
+
+func formatLimit(m types.Money) string {
+	if m.IsZero() {
+		return "-"
+	}
+	return m.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,167 @@
+// Command paygwd serves the payments SDK as a REST/JSON API and, optionally,
+// as the gatewayv1.GatewayService gRPC service.
+//
+// Provider credentials are loaded like sdk.Load: from the optional -config
+// file, overridden by PAYMENTS_* environment variables. Server settings:
+//
+//	PAYMENTS_SERVER_ADDR        listen address, default :8080 (-addr)
+//	PAYMENTS_SERVER_GRPC_ADDR   gRPC listen address, gRPC is off when empty (-grpc-addr)
+//	PAYMENTS_SERVER_API_KEYS    comma-separated API keys for both APIs, required
+//	PAYMENTS_SERVER_EVENTS_URL  URL receiving confirmed callbacks as JSON, optional
+//
+// Invoices created through the server are kept in memory, so provider
+// callbacks at /v1/callbacks/{type} are confirmed for invoices created since
+// the last start. The API is described at /openapi.yaml.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/grpcapi"
+	"github.com/techpartners-asia/payments-gateway/sdk/httpapi"
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
+	"github.com/techpartners-asia/payments-gateway/sdk/webhook"
+)
+
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "paygwd:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	configFile := flag.String("config", "", "YAML or JSON provider config file")
+	addr := flag.String("addr", envOr("PAYMENTS_SERVER_ADDR", ":8080"), "listen address")
+	grpcAddr := flag.String("grpc-addr", os.Getenv("PAYMENTS_SERVER_GRPC_ADDR"), "gRPC listen address, empty disables gRPC")
+	debug := flag.Bool("debug", false, "log provider request and response payloads")
+	flag.Parse()
+
+	level := slog.LevelInfo
+	if *debug {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	cfg, err := sdk.Load(sdk.LoadOptions{File: *configFile})
+	if err != nil {
+		return err
+	}
+	cfg.Logger = logger
+	// Clients retry timed-out requests; the store makes that safe for Create.
+	// A UID whose creation outcome is unknown is rejected until the
+	// reservation lapses after sdk.DefaultReservationTTL.
+	cfg.IdempotencyStore = sdk.NewMemoryIdempotencyStore()
+	// Callbacks are confirmed against the invoices recorded here.
+	cfg.Store = store.NewMemory()
+
+	gateway, err := sdk.NewGateway(cfg)
+	if err != nil {
+		return err
+	}
+
+	apiKeys := strings.Split(os.Getenv("PAYMENTS_SERVER_API_KEYS"), ",")
+	input := httpapi.Input{
+		SDK:     gateway,
+		Config:  cfg,
+		APIKeys: apiKeys,
+		Lookup:  webhook.StoreLookup(cfg.Store),
+		Logger:  logger,
+	}
+	if url := os.Getenv("PAYMENTS_SERVER_EVENTS_URL"); url != "" {
+		input.Events = httpapi.ForwardEvents(url, &http.Client{Timeout: 10 * time.Second})
+	}
+	api, err := httpapi.New(input)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           api,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var grpcServer *grpc.Server
+	if *grpcAddr != "" {
+		rpc, err := grpcapi.New(grpcapi.Input{
+			SDK:     gateway,
+			Config:  cfg,
+			APIKeys: apiKeys,
+			Logger:  logger,
+		})
+		if err != nil {
+			return err
+		}
+		grpcServer = grpc.NewServer(rpc.ServerOptions()...)
+		rpc.Register(grpcServer)
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		logger.Info("listening", "addr", *addr, "providers", gateway.Providers())
+		errs <- server.ListenAndServe()
+	}()
+	if grpcServer != nil {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		go func() {
+			logger.Info("listening for gRPC", "addr", *grpcAddr)
+			errs <- grpcServer.Serve(lis)
+		}()
+	}
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			// Open WatchInvoice streams would otherwise hold the shutdown.
+			grpcServer.Stop()
+		}
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
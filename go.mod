@@ -3,6 +3,7 @@ module github.com/techpartners-asia/payments-gateway
 go 1.25.4
 
 require (
+	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/techpartners-asia/balc-api-go v1.0.0
 	github.com/techpartners-asia/golomt-api-go v0.0.15
 	github.com/techpartners-asia/monpay-go v1.0.0
@@ -11,12 +12,28 @@ require (
 	github.com/techpartners-asia/simple-go v1.0.2
 	github.com/techpartners-asia/storepay-go v1.0.0
 	github.com/techpartners-asia/tokipay-go v1.0.0
+	go.opentelemetry.io/otel v1.44.0
+	go.opentelemetry.io/otel/metric v1.44.0
+	go.opentelemetry.io/otel/sdk v1.44.0
+	go.opentelemetry.io/otel/sdk/metric v1.44.0
+	go.opentelemetry.io/otel/trace v1.44.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260414002931-afd174a4e478
+	google.golang.org/grpc v1.82.1
+	google.golang.org/protobuf v1.36.11
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/schema v1.4.1 // indirect
-	golang.org/x/crypto v0.31.0 // indirect
-	golang.org/x/net v0.33.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	golang.org/x/crypto v0.50.0 // indirect
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.45.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
 	resty.dev/v3 v3.0.0-beta.3 // indirect
 )
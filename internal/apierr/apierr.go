@@ -0,0 +1,22 @@
+// Package apierr builds the request errors shared by the HTTP and gRPC
+// servers.
+package apierr
+
+import (
+	"errors"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// NoResult turns a nil result without an error into an error.
+func NoResult(err error) error {
+	if err == nil {
+		return errors.New("provider returned no result")
+	}
+	return err
+}
+
+// InvalidRequest reports a single malformed request field.
+func InvalidRequest(field, message string) error {
+	return &types.ValidationError{Fields: []*types.FieldError{{Field: field, Message: message}}}
+}
@@ -0,0 +1,29 @@
+// Package apikey checks the API keys of the HTTP and gRPC servers.
+package apikey
+
+import (
+	"crypto/subtle"
+	"strings"
+)
+
+// FromHeaders returns the key of a request: the bearer token of
+// authorization when present, otherwise the X-API-Key value.
+func FromHeaders(apiKey, authorization string) string {
+	if bearer, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+		return strings.TrimSpace(bearer)
+	}
+	return apiKey
+}
+
+// Valid reports whether key is one of keys. An empty key is never valid.
+func Valid(key string, keys []string) bool {
+	if key == "" {
+		return false
+	}
+	valid := 0
+	for _, candidate := range keys {
+		// Compare against every key so timing does not reveal which matched.
+		valid |= subtle.ConstantTimeCompare([]byte(key), []byte(candidate))
+	}
+	return valid == 1
+}
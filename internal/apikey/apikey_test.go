@@ -0,0 +1,42 @@
+package apikey
+
+import "testing"
+
+func TestFromHeaders(t *testing.T) {
+	tests := []struct {
+		apiKey, authorization, want string
+	}{
+		{apiKey: "key", want: "key"},
+		{authorization: "Bearer  token ", want: "token"},
+		{apiKey: "key", authorization: "Bearer token", want: "token"},
+		{apiKey: "key", authorization: "Basic dXNlcjpwYXNz", want: "key"},
+		{},
+	}
+	for _, tt := range tests {
+		if got := FromHeaders(tt.apiKey, tt.authorization); got != tt.want {
+			t.Errorf("FromHeaders(%q, %q) = %q, want %q", tt.apiKey, tt.authorization, got, tt.want)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	keys := []string{"first", "second"}
+	tests := []struct {
+		key  string
+		keys []string
+		want bool
+	}{
+		{key: "first", keys: keys, want: true},
+		{key: "second", keys: keys, want: true},
+		{key: "third", keys: keys},
+		{key: "firs", keys: keys},
+		{key: "", keys: keys},
+		{key: "", keys: []string{""}},
+		{key: "first"},
+	}
+	for _, tt := range tests {
+		if got := Valid(tt.key, tt.keys); got != tt.want {
+			t.Errorf("Valid(%q, %q) = %v, want %v", tt.key, tt.keys, got, tt.want)
+		}
+	}
+}
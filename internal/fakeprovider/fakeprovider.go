@@ -0,0 +1,120 @@
+// Package fakeprovider is a configurable in-memory PaymentProvider for tests.
+package fakeprovider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Answer is one scripted check outcome: Err when set, otherwise a result in
+// Status, pending when empty.
+type Answer struct {
+	Status types.PaymentStatus
+	Err    error
+}
+
+// Provider records the inputs it receives and answers with its fields. The
+// zero value creates every invoice and reports it pending.
+type Provider struct {
+	// ProviderInfo is returned by Info.
+	ProviderInfo types.ProviderInfo
+	// Validate, when set, is the provider's own ValidateInvoice rule.
+	Validate func(types.InvoiceInput) error
+
+	// CreateErrs are returned by successive creates, then nil.
+	CreateErrs []error
+	// Answers are returned by successive checks, repeating the last.
+	Answers []Answer
+	// Raw is the Raw of every result.
+	Raw any
+
+	mu      sync.Mutex
+	creates []types.InvoiceInput
+	checks  []types.CheckInvoiceInput
+}
+
+var (
+	_ types.PaymentProvider   = (*Provider)(nil)
+	_ types.ProviderDescriber = (*Provider)(nil)
+	_ types.InvoiceValidator  = (*Provider)(nil)
+)
+
+// Statuses scripts checks answering statuses in order.
+func Statuses(statuses ...types.PaymentStatus) []Answer {
+	answers := make([]Answer, len(statuses))
+	for i, status := range statuses {
+		answers[i] = Answer{Status: status}
+	}
+	return answers
+}
+
+func (p *Provider) Info() types.ProviderInfo {
+	return p.ProviderInfo
+}
+
+func (p *Provider) ValidateInvoice(input types.InvoiceInput) error {
+	if p.Validate == nil {
+		return nil
+	}
+	return p.Validate(input)
+}
+
+func (p *Provider) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.creates = append(p.creates, input)
+	if n := len(p.creates); n <= len(p.CreateErrs) && p.CreateErrs[n-1] != nil {
+		return nil, p.CreateErrs[n-1]
+	}
+	return &types.InvoiceResult{BankInvoiceID: "inv-" + input.UID, Raw: p.Raw}, nil
+}
+
+// CheckInvoiceContext answers with the next of Answers. A paid invoice
+// reports the checked amount as paid.
+func (p *Provider) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, input)
+	var answer Answer
+	if len(p.Answers) > 0 {
+		answer = p.Answers[min(len(p.checks), len(p.Answers))-1]
+	}
+	if answer.Err != nil {
+		return nil, answer.Err
+	}
+
+	result := &types.CheckInvoiceResult{Status: answer.Status, Raw: p.Raw}
+	if result.Status == "" {
+		result.Status = types.PaymentStatusPending
+	}
+	if result.Status == types.PaymentStatusPaid {
+		result.IsPaid = true
+		result.PaidAmount = input.Amount
+	}
+	return result, nil
+}
+
+// Creates returns the inputs of every create so far.
+func (p *Provider) Creates() []types.InvoiceInput {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]types.InvoiceInput(nil), p.creates...)
+}
+
+// Checks returns the inputs of every check so far.
+func (p *Provider) Checks() []types.CheckInvoiceInput {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]types.CheckInvoiceInput(nil), p.checks...)
+}
+
+// Reset forgets the recorded inputs and restarts the scripts.
+func (p *Provider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.creates, p.checks = nil, nil
+}
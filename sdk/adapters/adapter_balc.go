@@ -1,6 +1,7 @@
 package sdkAdapters
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
@@ -11,28 +12,66 @@ import (
 // BalcCreditAdapter implements PaymentProvider for Balc credit flow.
 type BalcCreditAdapter struct {
 	client balcapi.Balc
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*BalcCreditAdapter)(nil)
+	_ types.ProviderDescriber = (*BalcCreditAdapter)(nil)
+)
+
 func NewBalcCreditAdapter(input types.BalcAdapter) *BalcCreditAdapter {
-	return &BalcCreditAdapter{client: balcapi.New(input.Endpoint, input.Token)}
+	return &BalcCreditAdapter{
+		client:  balcapi.New(input.Endpoint, input.Token),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *BalcCreditAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:               types.PaymentTypeBalc,
+		DisplayName:        "Balc",
+		Operations:         []types.Operation{types.OperationCreate},
+		MinAmount:          types.MNT(1),
+		WholeAmount:        true,
+		RequiresCustomerID: true,
+	})
 }
 
 func (a *BalcCreditAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *BalcCreditAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("balc adapter not configured")
+		return nil, notConfigured(types.PaymentTypeBalc, types.OperationCreate)
 	}
 
-	creditCheck, err := a.client.LimitCheck(int(input.CustomerID))
+	amount, err := wholeAmount(types.PaymentTypeBalc, types.OperationCreate, input.Amount)
 	if err != nil {
-		return nil, fmt.Errorf("error on balcAPI check: %w", err)
+		return nil, err
 	}
-	if creditCheck.AvailLimit < input.Amount {
-		return nil, fmt.Errorf("таны кредит гүйлгээний дүнд хүрэхгүй байна")
+
+	creditCheck, err := callContext(ctx, types.PaymentTypeBalc, types.OperationCreate, func() (balcapi.LimitResponse, error) {
+		return a.client.LimitCheck(int(input.CustomerID))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("balc limit check: %w", err)
+	}
+	if mnt(creditCheck.AvailLimit).Cmp(input.Amount) < 0 {
+		return nil, &types.ProviderError{
+			Type:      types.PaymentTypeBalc,
+			Operation: types.OperationCreate,
+			Kind:      types.ErrInsufficientLimit,
+			Message:   fmt.Sprintf("available limit %s is below %s", mnt(creditCheck.AvailLimit), input.Amount),
+		}
 	}
 
-	loanAccountID, err := a.client.Loan(int(input.Amount), "Зээл", int(input.CustomerID))
+	loanAccountID, err := callContext(ctx, types.PaymentTypeBalc, types.OperationCreate, func() (string, error) {
+		return a.client.Loan(int(amount), "Зээл", int(input.CustomerID))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("зээл авахад алдаа гарлаа: %w", err)
+		return nil, fmt.Errorf("balc loan: %w", err)
 	}
 
 	return &types.InvoiceResult{
@@ -43,11 +82,11 @@ func (a *BalcCreditAdapter) CreateInvoice(input types.InvoiceInput) (*types.Invo
 }
 
 func (a *BalcCreditAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
-	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("balc adapter not configured")
-	}
+	return a.CheckInvoiceContext(context.Background(), input)
+}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: true,
-	}, nil
+// CheckInvoiceContext always fails: Balc has no status API. Its loans are
+// disbursed when the invoice is created, which reports them as paid.
+func (a *BalcCreditAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return nil, &types.NotSupportedError{Type: types.PaymentTypeBalc, Operation: types.OperationCheck}
 }
@@ -1,7 +1,10 @@
 package sdkAdapters
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
@@ -11,39 +14,86 @@ import (
 // GolomtAdapter implements PaymentProvider for Golomt ecommerce.
 type GolomtAdapter struct {
 	client golomt.GolomtEcommerce
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*GolomtAdapter)(nil)
+	_ types.ProviderDescriber = (*GolomtAdapter)(nil)
+)
+
+// golomtSuccess is the errorCode and statusCode of a successful Golomt
+// transaction.
+const golomtSuccess = "000"
+
 func NewGolomtAdapter(input types.GolomtAdapter) *GolomtAdapter {
-	return &GolomtAdapter{client: golomt.New(input.BaseURL, input.Secret, input.BearerToken)}
+	return &GolomtAdapter{
+		client:  golomt.New(input.BaseURL, input.Secret, input.BearerToken),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *GolomtAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:                types.PaymentTypeGolomt,
+		DisplayName:         "Golomt Bank",
+		MinAmount:           types.NewMoney(1, types.CurrencyMNT),
+		RequiresCallbackURL: true,
+	})
+}
+
+var _ types.InvoiceValidator = (*GolomtAdapter)(nil)
+
+func (a *GolomtAdapter) ValidateInvoice(input types.InvoiceInput) error {
+	var v types.Validator
+	if _, ok := golomtReturnType(input.ReturnType); !ok {
+		v.Add("ReturnType", "must be GET, POST or MOBILE")
+	}
+	return v.Err()
+}
+
+// golomtReturnType maps InvoiceInput.ReturnType, defaulting to GET.
+func golomtReturnType(value string) (golomt.ReturnType, bool) {
+	switch value {
+	case "", "GET", "get":
+		return golomt.GET, true
+	case "POST", "post":
+		return golomt.POST, true
+	case "MOBILE", "mobile":
+		return golomt.MOBILE, true
+	}
+	return "", false
 }
 
 func (a *GolomtAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *GolomtAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("golomt adapter not configured")
-	}
-
-	returnType := golomt.GET
-	if input.ReturnType != "" {
-		switch input.ReturnType {
-		case "GET", "get":
-			returnType = golomt.GET
-		case "POST", "post":
-			returnType = golomt.POST
-		case "MOBILE", "mobile":
-			returnType = golomt.MOBILE
-		default:
-			return nil, fmt.Errorf("invalid golomt return type: %s", input.ReturnType)
-		}
+		return nil, notConfigured(types.PaymentTypeGolomt, types.OperationCreate)
+	}
+
+	returnType, ok := golomtReturnType(input.ReturnType)
+	if !ok {
+		return nil, invalidInput(types.PaymentTypeGolomt, types.OperationCreate, "invalid return type: %s", input.ReturnType)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypeGolomt, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
 	}
 
 	req := golomt.CreateInvoiceInput{
 		ReturnType:    returnType,
-		Amount:        input.Amount,
+		Amount:        amount,
 		TransactionID: input.UID,
 		Callback:      input.CallbackURL,
 	}
 
-	res, err := a.client.CreateInvoice(req)
+	res, err := callContext(ctx, types.PaymentTypeGolomt, types.OperationCreate, func() (*golomt.CreateInvoiceResponse, error) {
+		return a.client.CreateInvoice(req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -56,16 +106,101 @@ func (a *GolomtAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceR
 }
 
 func (a *GolomtAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *GolomtAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("golomt adapter not configured")
+		return nil, notConfigured(types.PaymentTypeGolomt, types.OperationCheck)
 	}
 
-	res, err := a.client.Inquiry(input.UID)
+	res, err := callContext(ctx, types.PaymentTypeGolomt, types.OperationCheck, func() (*golomt.InquiryResponse, error) {
+		return a.client.Inquiry(input.UID)
+	})
+	if msg, ok := golomtNotPaid(err); ok {
+		return &types.CheckInvoiceResult{Status: types.PaymentStatusPending, Msg: msg}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: res.Status == "000",
+	// Inquiry only succeeds for errorCode "000", the transaction-success
+	// code. Status is documented as SENT or PENDING only.
+	if res.ErrorCode != golomtSuccess || strings.EqualFold(res.Status, "PENDING") {
+		return &types.CheckInvoiceResult{Status: types.PaymentStatusPending, Msg: res.ErrorDesc, Raw: res}, nil
+	}
+	result := &types.CheckInvoiceResult{
+		IsPaid:         true,
+		Status:         types.PaymentStatusPaid,
+		Msg:            res.ErrorDesc,
+		TransactionIDs: []string{res.TransactionID},
+		Raw:            res,
+	}
+	if amount, err := types.ParseMoney(res.Amount, types.CurrencyMNT); err == nil {
+		result.PaidAmount = amount
+	}
+	return result, nil
+}
+
+// golomtNotPaid reports whether err is golomt-api-go rejecting an Inquiry
+// answer whose errorCode is not "000", and returns its errorDesc. The
+// library turns such answers into a plain error holding errorDesc, so an
+// error that carries no transport, HTTP or context failure means the
+// transaction has not succeeded (yet). An answer failing its checksum is
+// treated the same way: it never marks the invoice paid.
+func golomtNotPaid(err error) (string, bool) {
+	var perr *types.ProviderError
+	if !errors.As(err, &perr) || perr.Kind != nil || perr.Err == nil ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+	return perr.Err.Error(), true
+}
+
+var _ types.InvoiceRefunder = (*GolomtAdapter)(nil)
+
+func (a *GolomtAdapter) RefundInvoice(input types.RefundInput) (*types.RefundResult, error) {
+	return a.RefundInvoiceContext(context.Background(), input)
+}
+
+// RefundInvoiceContext voids the whole card transaction; Golomt does not
+// support partial refunds, so a non-zero input.Amount must equal the paid
+// amount.
+func (a *GolomtAdapter) RefundInvoiceContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeGolomt, types.OperationRefund)
+	}
+
+	if !input.Amount.IsZero() {
+		inquiry, err := callContext(ctx, types.PaymentTypeGolomt, types.OperationRefund, func() (*golomt.InquiryResponse, error) {
+			return a.client.Inquiry(input.UID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		paid, err := types.ParseMoney(inquiry.Amount, types.CurrencyMNT)
+		if err != nil {
+			return nil, providerError(types.PaymentTypeGolomt, types.OperationRefund, fmt.Errorf("transaction amount: %w", err))
+		}
+		if !input.Amount.SameCurrency(paid) || input.Amount.Cmp(paid) != 0 {
+			return nil, invalidInput(types.PaymentTypeGolomt, types.OperationRefund, "golomt only refunds the whole transaction: amount %s does not match the paid %s", input.Amount, paid)
+		}
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeGolomt, types.OperationRefund, func() (*golomt.RefundResponse, error) {
+		return a.client.Refund(input.UID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != golomtSuccess {
+		return nil, declined(types.PaymentTypeGolomt, types.OperationRefund, res.StatusCode, res.Desc)
+	}
+	return &types.RefundResult{
+		IsRefunded: true,
+		RefundID:   res.TxnID,
+		Msg:        res.Desc,
+		Raw:        res,
 	}, nil
 }
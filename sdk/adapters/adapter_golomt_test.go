@@ -0,0 +1,132 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	golomt "github.com/techpartners-asia/golomt-api-go/ecommerce"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeGolomt answers Inquiry with inquiry and inquiryErr, and Refund with
+// refund.
+type fakeGolomt struct {
+	golomt.GolomtEcommerce
+
+	inquiry    *golomt.InquiryResponse
+	inquiryErr error
+	refund     *golomt.RefundResponse
+}
+
+func (g *fakeGolomt) Inquiry(transactionID string) (*golomt.InquiryResponse, error) {
+	return g.inquiry, g.inquiryErr
+}
+
+func (g *fakeGolomt) Refund(transactionID string) (*golomt.RefundResponse, error) {
+	return g.refund, nil
+}
+
+func TestGolomtCheckStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		inquiry    *golomt.InquiryResponse
+		inquiryErr error
+		want       types.PaymentStatus
+		wantMsg    string
+		wantErr    error
+	}{
+		{
+			name: "paid",
+			inquiry: &golomt.InquiryResponse{
+				Amount: "1000.00", Bank: "Golomt", Status: "SENT", ErrorDesc: "Гүйлгээ амжилттай", ErrorCode: "000",
+				CardHolder: "BAT", CardNumber: "4000-00XX-XXXX-0000", TransactionID: "order-1",
+			},
+			want:    types.PaymentStatusPaid,
+			wantMsg: "Гүйлгээ амжилттай",
+		},
+		{
+			name:    "paid without status",
+			inquiry: &golomt.InquiryResponse{Amount: "1000.00", ErrorCode: "000", TransactionID: "order-1"},
+			want:    types.PaymentStatusPaid,
+		},
+		{
+			name:    "payment pending",
+			inquiry: &golomt.InquiryResponse{Amount: "1000.00", Status: "PENDING", ErrorCode: "000", TransactionID: "order-1"},
+			want:    types.PaymentStatusPending,
+		},
+		{
+			// golomt-api-go returns errorDesc as the error for any errorCode
+			// other than "000".
+			name:       "not paid yet",
+			inquiryErr: fmt.Errorf("%s", "Гүйлгээ хийгдээгүй байна"),
+			want:       types.PaymentStatusPending,
+			wantMsg:    "Гүйлгээ хийгдээгүй байна",
+		},
+		{
+			name:       "checksum mismatch",
+			inquiryErr: errors.New("checksum verification failed"),
+			want:       types.PaymentStatusPending,
+			wantMsg:    "checksum verification failed",
+		},
+		{
+			name:       "unreachable",
+			inquiryErr: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			wantErr:    types.ErrProviderUnavailable,
+		},
+		{
+			name:       "timeout",
+			inquiryErr: fmt.Errorf("Post: %w", context.DeadlineExceeded),
+			wantErr:    context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &GolomtAdapter{client: &fakeGolomt{inquiry: tt.inquiry, inquiryErr: tt.inquiryErr}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			wantStatus(t, result, err, tt.want, types.MNT(1000))
+			if result.Msg != tt.wantMsg {
+				t.Errorf("msg = %q, want %q", result.Msg, tt.wantMsg)
+			}
+			if result.IsPaid && (len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "order-1") {
+				t.Errorf("transactions = %v, want [order-1]", result.TransactionIDs)
+			}
+		})
+	}
+}
+
+func TestGolomtRefund(t *testing.T) {
+	tests := []struct {
+		name     string
+		refund   *golomt.RefundResponse
+		wantCode string
+	}{
+		{name: "refunded", refund: &golomt.RefundResponse{StatusCode: "000", Desc: "Амжилттай", TxnID: "order-1"}},
+		{name: "declined", refund: &golomt.RefundResponse{StatusCode: "102", Desc: "Гүйлгээ олдсонгүй"}, wantCode: "102"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &GolomtAdapter{client: &fakeGolomt{refund: tt.refund}}
+			result, err := adapter.RefundInvoiceContext(context.Background(), types.RefundInput{UID: "order-1"})
+			if tt.wantCode == "" {
+				if err != nil || !result.IsRefunded || result.RefundID != "order-1" {
+					t.Fatalf("refund = %+v, %v, want refunded order-1", result, err)
+				}
+				return
+			}
+			var perr *types.ProviderError
+			if !errors.As(err, &perr) || perr.Code != tt.wantCode || perr.Message != tt.refund.Desc {
+				t.Fatalf("err = %v, want a *ProviderError with code %s and message %q", err, tt.wantCode, tt.refund.Desc)
+			}
+		})
+	}
+}
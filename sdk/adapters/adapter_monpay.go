@@ -1,45 +1,183 @@
 package sdkAdapters
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
 	"github.com/techpartners-asia/monpay-go/monpay"
 )
 
-// MonpayAdapter currently does not implement invoice creation because the library
-// exposes QR helpers rather than a direct create invoice API.
+// MonpayAdapter implements PaymentProvider for Monpay branch QR purchases,
+// or for Monpay deeplink invoices when the deeplink client is configured.
 type MonpayAdapter struct {
-	client monpay.Monpay
+	client   monpay.Monpay
+	deeplink monpay.Deeplink // nil unless configured
+	branch   string
+	display
 }
 
+// monpayQrNotScanned is the CheckQr code for a QR nobody has paid yet.
+const monpayQrNotScanned = 23
+
+var (
+	_ types.PaymentProvider   = (*MonpayAdapter)(nil)
+	_ types.ProviderDescriber = (*MonpayAdapter)(nil)
+)
+
 func NewMonpayAdapter(input types.MonpayAdapter) *MonpayAdapter {
-	return &MonpayAdapter{client: monpay.New(input.Endpoint, input.Username, input.AccountID, input.Callback)}
+	adapter := &MonpayAdapter{
+		client:  monpay.New(input.Endpoint, input.Username, input.AccountID, input.Callback),
+		branch:  input.Username,
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+	if input.Deeplink() {
+		adapter.deeplink = monpay.NewDeeplink(input.DeeplinkEndpoint, input.ClientID, input.ClientSecret, input.GrantType, input.Callback, input.RedirectURL)
+	}
+	return adapter
+}
+
+func (a *MonpayAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:        types.PaymentTypeMonpay,
+		DisplayName: "Monpay",
+		MinAmount:   types.MNT(1),
+		WholeAmount: true,
+	})
 }
 
 func (a *MonpayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
-	// res, err := a.client.GenerateQr(monpay.MonpayQrInput{
-	// 	Amount: input.Amount,
-	// })
-	// if err != nil {
-	// 	return nil, err
-	// }
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+// CreateInvoiceContext creates a branch QR, or a deeplink invoice when the
+// deeplink client is configured. Monpay reports no expiry for either, so
+// ExpiresAt is left unset.
+func (a *MonpayAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeMonpay, types.OperationCreate)
+	}
+
+	amount, err := wholeAmount(types.PaymentTypeMonpay, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.deeplink != nil {
+		return a.createDeeplink(ctx, input, amount)
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeMonpay, types.OperationCreate, func() (monpay.MonpayQrResponse, error) {
+		return a.client.GenerateQr(monpay.MonpayQrInput{
+			Amount: float64(amount),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.InvoiceResult{
+		BankInvoiceID: res.Result.UUID,
+		BankQRCode:    res.Result.Qrcode,
+		IsPaid:        false,
+		Raw:           res,
+	}, nil
+}
+
+// createDeeplink creates a deeplink invoice. Its id is the BankInvoiceID;
+// Monpay returns no link or QR for it.
+func (a *MonpayAdapter) createDeeplink(ctx context.Context, input types.InvoiceInput, amount int64) (*types.InvoiceResult, error) {
+	res, err := callContext(ctx, types.PaymentTypeMonpay, types.OperationCreate, func() (monpay.DeeplinkCreateResponse, error) {
+		return a.deeplink.CreateDeeplink(float64(amount), monpay.P2B, a.branch, input.Note, input.UID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Result.ID == 0 {
+		return nil, providerError(types.PaymentTypeMonpay, types.OperationCreate, errors.New("monpay deeplink: "+res.Info))
+	}
 
-	return nil, fmt.Errorf("monpay create invoice is not implemented; use monpay.GenerateQr or other helpers directly")
+	return &types.InvoiceResult{
+		BankInvoiceID: strconv.Itoa(res.Result.ID),
+		IsPaid:        false,
+		Raw:           res,
+	}, nil
 }
 
 func (a *MonpayAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *MonpayAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("monpay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeMonpay, types.OperationCheck)
 	}
 
-	res, err := a.client.CheckQr(input.UID)
+	if a.deeplink != nil {
+		return a.checkDeeplink(ctx, input)
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeMonpay, types.OperationCheck, func() (monpay.MonpayCheckResponse, error) {
+		res, err := a.client.CheckQr(input.UID)
+		if err != nil && res.Code == monpayQrNotScanned {
+			return res, nil
+		}
+		return res, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
+	result := &types.CheckInvoiceResult{
 		IsPaid: res.Code == 0,
-	}, nil
+		Status: types.PaymentStatusPending,
+		Msg:    res.Info,
+		Raw:    res,
+	}
+	if result.IsPaid {
+		result.Status = types.PaymentStatusPaid
+		result.PaidAmount = types.MNT(res.Result.Amount)
+		result.PaidAt = unixMillis(res.Result.UsedAt)
+		if res.Result.TransactionId != "" {
+			result.TransactionIDs = []string{res.Result.TransactionId}
+		}
+	}
+	return result, nil
+}
+
+// checkDeeplink checks a deeplink invoice by the id createDeeplink returned.
+func (a *MonpayAdapter) checkDeeplink(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	id, err := strconv.Atoi(input.UID)
+	if err != nil {
+		return nil, invalidInput(types.PaymentTypeMonpay, types.OperationCheck, "deeplink invoice id %q is not a number", input.UID)
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeMonpay, types.OperationCheck, func() (monpay.DeeplinkCheckResponse, error) {
+		return a.deeplink.CheckInvoice(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := types.PaymentStatusPending
+	switch strings.ToUpper(res.Result.Status) {
+	case "PAID":
+		status = types.PaymentStatusPaid
+	case "FAILED":
+		status = types.PaymentStatusFailed
+	}
+
+	result := &types.CheckInvoiceResult{
+		IsPaid: status == types.PaymentStatusPaid,
+		Status: status,
+		Msg:    res.Result.StatusInfo,
+		Raw:    res,
+	}
+	if result.IsPaid {
+		result.PaidAmount = mnt(res.Result.Amount)
+	}
+	return result, nil
 }
@@ -0,0 +1,90 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/techpartners-asia/monpay-go/monpay"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeMonpay answers CheckQr with code the way monpay-go does: an error for
+// any code but 0.
+type fakeMonpay struct {
+	monpay.Monpay
+
+	code int
+}
+
+func (m fakeMonpay) CheckQr(uuid string) (monpay.MonpayCheckResponse, error) {
+	res := monpay.MonpayCheckResponse{Code: m.code, Info: "info"}
+	switch m.code {
+	case 0:
+		res.Result = monpay.MonpayResultCheck{UUID: uuid, TransactionId: "tx-1", Amount: 4000, UsedAt: 1714559400000}
+		return res, nil
+	case monpayQrNotScanned:
+		return res, errors.New("QR not scanned")
+	}
+	return res, errors.New("дотоод алдаа")
+}
+
+// fakeDeeplink answers CheckInvoice with a 4000 MNT invoice in status.
+type fakeDeeplink struct {
+	monpay.Deeplink
+
+	status string
+}
+
+func (d fakeDeeplink) CheckInvoice(invoiceID int) (monpay.DeeplinkCheckResponse, error) {
+	return monpay.DeeplinkCheckResponse{Code: "ok", Result: monpay.DeeplinkCheckResult{ID: invoiceID, Amount: 4000, Status: d.status}}, nil
+}
+
+func TestMonpayCheckStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    int
+		want    types.PaymentStatus
+		wantErr bool
+	}{
+		{name: "paid", code: 0, want: types.PaymentStatusPaid},
+		{name: "not scanned", code: monpayQrNotScanned, want: types.PaymentStatusPending},
+		{name: "internal error", code: 999, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &MonpayAdapter{client: fakeMonpay{code: tt.code}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "qr-1"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("result = %+v, want an error", result)
+				}
+				return
+			}
+			wantStatus(t, result, err, tt.want, types.MNT(4000))
+			if result.IsPaid && (result.PaidAt == nil || result.PaidAt.UnixMilli() != 1714559400000 || len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "tx-1") {
+				t.Errorf("paid at = %v, transactions = %v, want 1714559400000, [tx-1]", result.PaidAt, result.TransactionIDs)
+			}
+		})
+	}
+}
+
+func TestMonpayDeeplinkCheckStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   types.PaymentStatus
+	}{
+		{status: "NEW", want: types.PaymentStatusPending},
+		{status: "PAID", want: types.PaymentStatusPaid},
+		{status: "paid", want: types.PaymentStatusPaid},
+		{status: "FAILED", want: types.PaymentStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			adapter := &MonpayAdapter{client: fakeMonpay{}, deeplink: fakeDeeplink{status: tt.status}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "17"})
+			wantStatus(t, result, err, tt.want, types.MNT(4000))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package sdkAdapters
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
@@ -11,26 +13,54 @@ import (
 // PocketAdapter implements PaymentProvider for Pocket.
 type PocketAdapter struct {
 	client pocket.Pocket
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*PocketAdapter)(nil)
+	_ types.ProviderDescriber = (*PocketAdapter)(nil)
+)
+
 func NewPocketAdapter(input types.PocketAdapter) *PocketAdapter {
-	return &PocketAdapter{client: pocket.New(input.Merchant, input.ClientID, input.ClientSecret, input.Environment, input.TerminalIDRaw)}
+	return &PocketAdapter{
+		client:  pocket.New(input.Merchant, input.ClientID, input.ClientSecret, input.Environment, input.TerminalIDRaw),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *PocketAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:        types.PaymentTypePocket,
+		DisplayName: "Pocket",
+		MinAmount:   types.NewMoney(1, types.CurrencyMNT),
+	})
 }
 
 func (a *PocketAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *PocketAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("pocket adapter not configured")
+		return nil, notConfigured(types.PaymentTypePocket, types.OperationCreate)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypePocket, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
 	}
 
 	req := pocket.PocketCreateInvoiceInput{
-		Amount:      input.Amount,
+		Amount:      amount,
 		OrderNumber: input.UID,
 		InvoiceType: "ZERO",
 		Channel:     "merchant",
 		Info:        input.Note,
 	}
 
-	res, err := a.client.CreateInvoice(req)
+	res, err := callContext(ctx, types.PaymentTypePocket, types.OperationCreate, func() (pocket.PocketCreateInvoiceResponse, error) {
+		return a.client.CreateInvoice(req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -49,16 +79,44 @@ func (a *PocketAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceR
 }
 
 func (a *PocketAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *PocketAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("pocket adapter not configured")
+		return nil, notConfigured(types.PaymentTypePocket, types.OperationCheck)
 	}
 
-	res, err := a.client.GetInvoiceByOrderNumber(input.UID)
+	res, err := callContext(ctx, types.PaymentTypePocket, types.OperationCheck, func() (pocket.PocketInvoiceDetailResponse, error) {
+		return a.client.GetInvoiceByOrderNumber(input.UID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: res.State == "paid",
-	}, nil
+	status := types.PaymentStatusPending
+	switch strings.ToLower(res.State) {
+	case "paid":
+		status = types.PaymentStatusPaid
+	case "failed":
+		status = types.PaymentStatusFailed
+	case "expired":
+		status = types.PaymentStatusExpired
+	case "cancelled", "canceled":
+		status = types.PaymentStatusCancelled
+	case "refunded":
+		status = types.PaymentStatusRefunded
+	}
+
+	result := &types.CheckInvoiceResult{
+		IsPaid: status == types.PaymentStatusPaid,
+		Status: status,
+		Msg:    res.Description,
+		Raw:    res,
+	}
+	if result.IsPaid {
+		result.PaidAmount = mnt(res.Amount)
+		result.TransactionIDs = []string{fmt.Sprintf("%d", res.ID)}
+	}
+	return result, nil
 }
@@ -0,0 +1,49 @@
+package sdkAdapters
+
+import (
+	"context"
+	"testing"
+
+	pocket "github.com/techpartners-asia/pocket-go"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakePocket answers GetInvoiceByOrderNumber with a 1500 MNT invoice in
+// state.
+type fakePocket struct {
+	pocket.Pocket
+
+	state string
+}
+
+func (p fakePocket) GetInvoiceByOrderNumber(orderNumber string) (pocket.PocketInvoiceDetailResponse, error) {
+	return pocket.PocketInvoiceDetailResponse{ID: 42, State: p.state, Amount: 1500, OrderNumber: orderNumber}, nil
+}
+
+func TestPocketCheckStatus(t *testing.T) {
+	tests := []struct {
+		state string
+		want  types.PaymentStatus
+	}{
+		{state: "pending", want: types.PaymentStatusPending},
+		{state: "", want: types.PaymentStatusPending},
+		{state: "paid", want: types.PaymentStatusPaid},
+		{state: "PAID", want: types.PaymentStatusPaid},
+		{state: "failed", want: types.PaymentStatusFailed},
+		{state: "expired", want: types.PaymentStatusExpired},
+		{state: "cancelled", want: types.PaymentStatusCancelled},
+		{state: "canceled", want: types.PaymentStatusCancelled},
+		{state: "refunded", want: types.PaymentStatusRefunded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			adapter := &PocketAdapter{client: fakePocket{state: tt.state}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"})
+			wantStatus(t, result, err, tt.want, types.MNT(1500))
+			if result.IsPaid && (len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "42") {
+				t.Errorf("transactions = %v, want [42]", result.TransactionIDs)
+			}
+		})
+	}
+}
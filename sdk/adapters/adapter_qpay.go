@@ -1,8 +1,9 @@
 package sdkAdapters
 
 import (
+	"context"
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
@@ -12,19 +13,47 @@ import (
 // QPayAdapter implements PaymentProvider for QPay.
 type QPayAdapter struct {
 	client qpay_v2.QPay
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*QPayAdapter)(nil)
+	_ types.ProviderDescriber = (*QPayAdapter)(nil)
+)
+
 func NewQPayAdapter(input types.QpayAdapter) *QPayAdapter {
 	if input.Username == "" || input.Password == "" || input.Endpoint == "" || input.InvoiceCode == "" || input.MerchantID == "" {
 		return nil
 	}
-	return &QPayAdapter{client: qpay_v2.New(input.Username, input.Password, input.Endpoint, input.Callback, input.InvoiceCode, input.MerchantID)}
+	return &QPayAdapter{
+		client:  qpay_v2.New(input.Username, input.Password, input.Endpoint, input.Callback, input.InvoiceCode, input.MerchantID),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *QPayAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:                types.PaymentTypeQPay,
+		DisplayName:         "QPay",
+		MinAmount:           types.MNT(1),
+		WholeAmount:         true,
+		CheckRequiresAmount: true,
+	})
 }
 
 func (a *QPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *QPayAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("qpay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeQPay, types.OperationCreate)
 	}
+	amount, err := wholeAmount(types.PaymentTypeQPay, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
 	// prefix := "personal"
 	// if input.IsOrg && input.OrgRegNo != "" {
 	// 	prefix = input.OrgRegNo
@@ -33,10 +62,13 @@ func (a *QPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceRes
 		SenderCode:    input.UID,
 		ReceiverCode:  input.UID,
 		Description:   input.Note,
-		Amount:        int64(input.Amount),
+		Amount:        amount,
 		CallbackParam: map[string]string{"uid": input.UID},
 	}
-	res, _, err := a.client.CreateInvoice(qpayInput)
+	res, err := callContext(ctx, types.PaymentTypeQPay, types.OperationCreate, func() (qpay_v2.QPaySimpleInvoiceResponse, error) {
+		res, _, err := a.client.CreateInvoice(qpayInput)
+		return res, err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -61,25 +93,165 @@ func (a *QPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceRes
 }
 
 func (a *QPayAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+// CheckInvoiceContext sums the PAID payments of the invoice and reports it
+// paid once they cover input.Amount, which is therefore required.
+func (a *QPayAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("qpay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeQPay, types.OperationCheck)
+	}
+	if err := checkAmount(types.PaymentTypeQPay, types.OperationCheck, input.Amount); err != nil {
+		return nil, err
 	}
 
-	res, _, err := a.client.CheckPayment(input.UID, 100, 1)
+	res, err := callContext(ctx, types.PaymentTypeQPay, types.OperationCheck, func() (qpay_v2.QpayPaymentCheckResponse, error) {
+		res, _, err := a.client.CheckPayment(input.UID, 100, 1)
+		return res, err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	amount := float64(0)
+	result := &types.CheckInvoiceResult{
+		Raw: res,
+	}
+	var refunded, failed bool
 	for _, row := range res.Rows {
-		if row.PaymentStatus == "PAID" {
-			if _amount, err := strconv.ParseFloat(row.PaymentAmount, 64); err == nil {
-				amount += _amount
+		switch row.PaymentStatus {
+		case "PAID":
+			if amount, err := types.ParseMoney(row.PaymentAmount, row.PaymentCurrency); err == nil {
+				result.PaidAmount = result.PaidAmount.Add(amount)
+			}
+			if paidAt := parseProviderTime(row.PaymentDate); paidAt != nil && (result.PaidAt == nil || paidAt.After(*result.PaidAt)) {
+				result.PaidAt = paidAt
+			}
+			result.TransactionIDs = append(result.TransactionIDs, row.PaymentID)
+		case "REFUNDED":
+			refunded = true
+		case "FAILED":
+			failed = true
+		}
+	}
+
+	switch {
+	case result.PaidAmount.IsPositive() && result.PaidAmount.SameCurrency(input.Amount) && result.PaidAmount.Cmp(input.Amount) >= 0:
+		result.Status = types.PaymentStatusPaid
+	case result.PaidAmount.IsPositive():
+		result.Status = types.PaymentStatusPartiallyPaid
+	case refunded:
+		result.Status = types.PaymentStatusRefunded
+	case failed:
+		result.Status = types.PaymentStatusFailed
+	default:
+		result.Status = types.PaymentStatusPending
+	}
+	result.IsPaid = result.Status == types.PaymentStatusPaid
+
+	return result, nil
+}
+
+var (
+	_ types.InvoiceRefunder = (*QPayAdapter)(nil)
+	_ types.InvoiceCanceler = (*QPayAdapter)(nil)
+)
+
+func (a *QPayAdapter) RefundInvoice(input types.RefundInput) (*types.RefundResult, error) {
+	return a.RefundInvoiceContext(context.Background(), input)
+}
+
+// RefundInvoiceContext refunds input.PaymentID, or every paid payment of the
+// invoice when it is empty. QPay only refunds whole payments, so a non-zero
+// input.Amount must equal the paid amount of the payments refunded. When a
+// refund fails after others went through, the result listing the completed
+// refunds is returned together with the error.
+func (a *QPayAdapter) RefundInvoiceContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeQPay, types.OperationRefund)
+	}
+
+	paymentIDs := []string{input.PaymentID}
+	if input.PaymentID == "" || !input.Amount.IsZero() {
+		res, err := callContext(ctx, types.PaymentTypeQPay, types.OperationRefund, func() (qpay_v2.QpayPaymentCheckResponse, error) {
+			res, _, err := a.client.CheckPayment(input.UID, 100, 1)
+			return res, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		paymentIDs = paymentIDs[:0]
+		var paid types.Money
+		for _, row := range res.Rows {
+			if row.PaymentStatus != "PAID" || (input.PaymentID != "" && row.PaymentID != input.PaymentID) {
+				continue
+			}
+			amount, err := types.ParseMoney(row.PaymentAmount, row.PaymentCurrency)
+			if err != nil {
+				return nil, providerError(types.PaymentTypeQPay, types.OperationRefund, fmt.Errorf("payment %s amount: %w", row.PaymentID, err))
 			}
+			paymentIDs = append(paymentIDs, row.PaymentID)
+			paid = paid.Add(amount)
 		}
+		switch {
+		case len(paymentIDs) == 0 && input.PaymentID != "":
+			return nil, invalidInput(types.PaymentTypeQPay, types.OperationRefund, "invoice %s has no paid payment %s to refund", input.UID, input.PaymentID)
+		case len(paymentIDs) == 0:
+			return nil, invalidInput(types.PaymentTypeQPay, types.OperationRefund, "invoice %s has no paid payments to refund", input.UID)
+		case !input.Amount.IsZero() && (!input.Amount.SameCurrency(paid) || input.Amount.Cmp(paid) != 0):
+			return nil, invalidInput(types.PaymentTypeQPay, types.OperationRefund, "qpay only refunds whole payments: amount %s does not match the paid %s", input.Amount, paid)
+		}
+	}
+
+	raw := make([]any, 0, len(paymentIDs))
+	for i, paymentID := range paymentIDs {
+		res, err := callContext(ctx, types.PaymentTypeQPay, types.OperationRefund, func() (any, error) {
+			// qpay-go puts the first argument in the /payment/refund/{id} URL and
+			// appends the second to the refund callback URL.
+			res, _, err := a.client.RefundPayment(paymentID, paymentID)
+			return res, err
+		})
+		if err != nil {
+			err = fmt.Errorf("qpay refund payment %s: %w", paymentID, err)
+			if i == 0 {
+				return nil, err
+			}
+			return &types.RefundResult{
+				RefundID: strings.Join(paymentIDs[:i], ","),
+				Msg:      fmt.Sprintf("refunded %d of %d payments", i, len(paymentIDs)),
+				Raw:      raw,
+			}, err
+		}
+		raw = append(raw, res)
+	}
+
+	return &types.RefundResult{
+		IsRefunded: true,
+		RefundID:   strings.Join(paymentIDs, ","),
+		Raw:        raw,
+	}, nil
+}
+
+func (a *QPayAdapter) CancelInvoice(input types.CancelInput) (*types.CancelResult, error) {
+	return a.CancelInvoiceContext(context.Background(), input)
+}
+
+func (a *QPayAdapter) CancelInvoiceContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeQPay, types.OperationCancel)
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeQPay, types.OperationCancel, func() (any, error) {
+		res, _, err := a.client.CancelInvoice(input.UID)
+		return res, err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: amount >= input.Amount,
+	return &types.CancelResult{
+		IsCancelled: true,
+		Raw:         res,
 	}, nil
 }
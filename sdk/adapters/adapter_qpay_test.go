@@ -0,0 +1,104 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	qpay_v2 "github.com/techpartners-asia/qpay-go/qpay_v2"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeQPay serves CheckPayment from rows and fails RefundPayment for the
+// payment ids in refundErrs.
+type fakeQPay struct {
+	qpay_v2.QPay
+
+	rows       []*qpay_v2.QpayRow
+	refundErrs map[string]error
+	refunded   []string
+}
+
+func (q *fakeQPay) CheckPayment(invoiceID string, pageLimit, pageNumber int64) (qpay_v2.QpayPaymentCheckResponse, qpay_v2.QPay, error) {
+	return qpay_v2.QpayPaymentCheckResponse{Count: int64(len(q.rows)), Rows: q.rows}, q, nil
+}
+
+func (q *fakeQPay) RefundPayment(paymentID, callbackParam string) (interface{}, qpay_v2.QPay, error) {
+	if err := q.refundErrs[paymentID]; err != nil {
+		return nil, q, err
+	}
+	q.refunded = append(q.refunded, paymentID)
+	return map[string]string{"payment_id": paymentID}, q, nil
+}
+
+func TestQPayRefund(t *testing.T) {
+	rows := []*qpay_v2.QpayRow{
+		{PaymentID: "p1", PaymentStatus: "PAID", PaymentAmount: "1000", PaymentCurrency: "MNT"},
+		{PaymentID: "p2", PaymentStatus: "PAID", PaymentAmount: "500", PaymentCurrency: "MNT"},
+		{PaymentID: "p3", PaymentStatus: "FAILED", PaymentAmount: "500", PaymentCurrency: "MNT"},
+	}
+	tests := []struct {
+		name         string
+		input        types.RefundInput
+		refundErrs   map[string]error
+		wantRefunded []string
+		wantRefundID string
+		wantInvalid  bool
+		wantErr      bool
+	}{
+		{name: "all payments", input: types.RefundInput{UID: "inv"}, wantRefunded: []string{"p1", "p2"}, wantRefundID: "p1,p2"},
+		{name: "all payments exact amount", input: types.RefundInput{UID: "inv", Amount: types.MNT(1500)}, wantRefunded: []string{"p1", "p2"}, wantRefundID: "p1,p2"},
+		{name: "partial amount", input: types.RefundInput{UID: "inv", Amount: types.MNT(1)}, wantInvalid: true},
+		{name: "one payment", input: types.RefundInput{UID: "inv", PaymentID: "p2"}, wantRefunded: []string{"p2"}, wantRefundID: "p2"},
+		{name: "one payment exact amount", input: types.RefundInput{UID: "inv", PaymentID: "p2", Amount: types.MNT(500)}, wantRefunded: []string{"p2"}, wantRefundID: "p2"},
+		{name: "one payment partial amount", input: types.RefundInput{UID: "inv", PaymentID: "p1", Amount: types.MNT(500)}, wantInvalid: true},
+		{name: "unpaid payment", input: types.RefundInput{UID: "inv", PaymentID: "p3", Amount: types.MNT(500)}, wantInvalid: true},
+		{
+			name:         "failure after first refund",
+			input:        types.RefundInput{UID: "inv"},
+			refundErrs:   map[string]error{"p2": errors.New("boom")},
+			wantRefunded: []string{"p1"},
+			wantRefundID: "p1",
+			wantErr:      true,
+		},
+		{
+			name:       "failure on first refund",
+			input:      types.RefundInput{UID: "inv"},
+			refundErrs: map[string]error{"p1": errors.New("boom")},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeQPay{rows: rows, refundErrs: tt.refundErrs}
+			adapter := &QPayAdapter{client: client}
+
+			result, err := adapter.RefundInvoiceContext(context.Background(), tt.input)
+			switch {
+			case tt.wantInvalid:
+				if !errors.Is(err, types.ErrInvalidInput) || len(client.refunded) != 0 {
+					t.Fatalf("err = %v, refunded = %v, want ErrInvalidInput before refunding", err, client.refunded)
+				}
+				return
+			case tt.wantErr && err == nil:
+				t.Fatal("want an error")
+			case !tt.wantErr && err != nil:
+				t.Fatalf("refund: %v", err)
+			}
+
+			if len(client.refunded) != len(tt.wantRefunded) {
+				t.Fatalf("refunded = %v, want %v", client.refunded, tt.wantRefunded)
+			}
+			if tt.wantRefundID == "" {
+				if result != nil {
+					t.Fatalf("result = %+v, want nil", result)
+				}
+				return
+			}
+			if result == nil || result.RefundID != tt.wantRefundID || result.IsRefunded == tt.wantErr {
+				t.Fatalf("result = %+v, want refund id %q, refunded %v", result, tt.wantRefundID, !tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,8 @@
 package sdkAdapters
 
 import (
-	"fmt"
+	"context"
+	"strings"
 	"time"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
@@ -9,29 +10,64 @@ import (
 	simple "github.com/techpartners-asia/simple-go"
 )
 
+const simpleDefaultExpireMinutes = 20
+
 // SimpleAdapter implements PaymentProvider for Simple.
 type SimpleAdapter struct {
 	client simple.Simple
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*SimpleAdapter)(nil)
+	_ types.ProviderDescriber = (*SimpleAdapter)(nil)
+)
+
 func NewSimpleAdapter(input types.SimpleAdapter) *SimpleAdapter {
-	return &SimpleAdapter{client: simple.New(input.UserName, input.Password, input.BaseUrl, input.CallbackUrl)}
+	return &SimpleAdapter{
+		client:  simple.New(input.UserName, input.Password, input.BaseUrl, input.CallbackUrl),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *SimpleAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:        types.PaymentTypeSimple,
+		DisplayName: "Simple",
+		MinAmount:   types.MNT(1),
+		WholeAmount: true,
+	})
 }
 
 func (a *SimpleAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *SimpleAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("simple adapter not configured")
+		return nil, notConfigured(types.PaymentTypeSimple, types.OperationCreate)
+	}
+
+	amount, err := wholeAmount(types.PaymentTypeSimple, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
 	}
 
-	expireAt := time.Now().Add(20 * time.Minute).Format("2006-01-02 15:04:05")
+	expireMinutes := simpleDefaultExpireMinutes
+	if input.ExpireMinutes > 0 {
+		expireMinutes = input.ExpireMinutes
+	}
+	expiresAt := time.Now().Add(time.Duration(expireMinutes) * time.Minute)
 
 	req := simple.SimpleCreateInvoiceInput{
 		OrderID:    input.UID,
-		Total:      int(input.Amount),
-		ExpireDate: expireAt,
+		Total:      int(amount),
+		ExpireDate: expiresAt.Format("2006-01-02 15:04:05"),
 	}
 
-	res, err := a.client.CreateInvoice(req)
+	res, err := callContext(ctx, types.PaymentTypeSimple, types.OperationCreate, func() (simple.SimpleCreateInvoiceResponse, error) {
+		return a.client.CreateInvoice(req)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -40,23 +76,51 @@ func (a *SimpleAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceR
 		BankInvoiceID: input.UID,
 		Raw:           res,
 		IsPaid:        false,
+		ExpiresAt:     &expiresAt,
 	}, nil
 }
 
 func (a *SimpleAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *SimpleAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("simple adapter not configured")
+		return nil, notConfigured(types.PaymentTypeSimple, types.OperationCheck)
 	}
 
-	res, err := a.client.GetInvoice(simple.SimpleGetInvoiceRequest{
-		OrderID:  input.UID,
-		SimpleID: "",
+	res, err := callContext(ctx, types.PaymentTypeSimple, types.OperationCheck, func() (simple.SimpleSendInvoiceToNumberResponse, error) {
+		return a.client.GetInvoice(simple.SimpleGetInvoiceRequest{
+			OrderID:  input.UID,
+			SimpleID: "",
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: res.Data.InvoiceStatus == "PAID",
-	}, nil
+	status := types.PaymentStatusPending
+	switch strings.ToUpper(res.Data.InvoiceStatus) {
+	case "PAID", "COMPLETED":
+		status = types.PaymentStatusPaid
+	case "EXPIRED":
+		status = types.PaymentStatusExpired
+	case "CANCELED", "CANCELLED":
+		status = types.PaymentStatusCancelled
+	}
+
+	result := &types.CheckInvoiceResult{
+		IsPaid: status == types.PaymentStatusPaid,
+		Status: status,
+		Msg:    res.Message,
+		Raw:    res,
+	}
+	if result.IsPaid {
+		result.PaidAmount = mnt(res.Data.Total)
+		if paidDate, ok := res.Data.PaidDate.(string); ok {
+			result.PaidAt = parseProviderTime(paidDate)
+		}
+		result.TransactionIDs = []string{res.Data.InvoiceUUID}
+	}
+	return result, nil
 }
@@ -0,0 +1,64 @@
+package sdkAdapters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	simple "github.com/techpartners-asia/simple-go"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeSimple answers GetInvoice with a 2500 MNT invoice in status.
+type fakeSimple struct {
+	simple.Simple
+
+	status string
+}
+
+func (s fakeSimple) GetInvoice(input simple.SimpleGetInvoiceRequest) (simple.SimpleSendInvoiceToNumberResponse, error) {
+	return simple.SimpleSendInvoiceToNumberResponse{
+		Code:    "200",
+		Message: "success",
+		Data: simple.SimpleSendInvoiceToNumberData{
+			InvoiceUUID:   "uuid-1",
+			OrderID:       input.OrderID,
+			Total:         2500,
+			PaidDate:      "2024-05-01 10:30:00",
+			InvoiceStatus: s.status,
+		},
+	}, nil
+}
+
+func TestSimpleCheckStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   types.PaymentStatus
+	}{
+		{status: "PENDING", want: types.PaymentStatusPending},
+		{status: "SENT", want: types.PaymentStatusPending},
+		{status: "PAID", want: types.PaymentStatusPaid},
+		{status: "COMPLETED", want: types.PaymentStatusPaid},
+		{status: "EXPIRED", want: types.PaymentStatusExpired},
+		{status: "CANCELED", want: types.PaymentStatusCancelled},
+		{status: "CANCELLED", want: types.PaymentStatusCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			adapter := &SimpleAdapter{client: fakeSimple{status: tt.status}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"})
+			wantStatus(t, result, err, tt.want, types.MNT(2500))
+			if !result.IsPaid {
+				return
+			}
+			paidAt := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+			if result.PaidAt == nil || !result.PaidAt.Equal(paidAt) {
+				t.Errorf("paid at = %v, want %v", result.PaidAt, paidAt)
+			}
+			if len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "uuid-1" {
+				t.Errorf("transactions = %v, want [uuid-1]", result.TransactionIDs)
+			}
+		})
+	}
+}
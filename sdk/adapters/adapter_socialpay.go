@@ -1,7 +1,7 @@
 package sdkAdapters
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
@@ -11,20 +11,52 @@ import (
 // SocialPayAdapter implements PaymentProvider for SocialPay.
 type SocialPayAdapter struct {
 	client socialpay.SocialPay
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*SocialPayAdapter)(nil)
+	_ types.ProviderDescriber = (*SocialPayAdapter)(nil)
+)
+
+// socialPayApproved is the card approval response code.
+const socialPayApproved = "00"
+
 func NewSocialPayAdapter(input types.SocialPayAdapter) *SocialPayAdapter {
-	return &SocialPayAdapter{client: socialpay.New(input.Terminal, input.Secret, input.Endpoint)}
+	return &SocialPayAdapter{
+		client:  socialpay.New(input.Terminal, input.Secret, input.Endpoint),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *SocialPayAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:                types.PaymentTypeSocial,
+		DisplayName:         "SocialPay",
+		MinAmount:           types.NewMoney(1, types.CurrencyMNT),
+		CheckRequiresAmount: true,
+	})
 }
 
 func (a *SocialPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *SocialPayAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("socialpay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeSocial, types.OperationCreate)
 	}
 
-	res, err := a.client.CreateInvoiceQR(socialpay.InvoiceInput{
-		Amount:  input.Amount,
-		Invoice: input.UID,
+	amount, err := decimalAmount(types.PaymentTypeSocial, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeSocial, types.OperationCreate, func() (*socialpay.CommonResponse, error) {
+		return a.client.CreateInvoiceQR(socialpay.InvoiceInput{
+			Amount:  amount,
+			Invoice: input.UID,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -39,19 +71,113 @@ func (a *SocialPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.Invoi
 }
 
 func (a *SocialPayAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *SocialPayAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("socialpay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeSocial, types.OperationCheck)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypeSocial, types.OperationCheck, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeSocial, types.OperationCheck, func() (*socialpay.InvoiceResponse, error) {
+		return a.client.CheckInvoice(socialpay.InvoiceInput{
+			Invoice: input.UID,
+			Amount:  amount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Anything but the approval code means the invoice has not been paid
+	// (yet), so it stays pending.
+	result := &types.CheckInvoiceResult{
+		IsPaid: res.ResponseCode == socialPayApproved,
+		Status: types.PaymentStatusPending,
+		Msg:    res.ResponseDescription,
+		Raw:    res,
+	}
+	if result.IsPaid {
+		result.Status = types.PaymentStatusPaid
+		result.PaidAmount = mnt(res.Amount)
+		result.TransactionIDs = []string{res.ApprovalCode}
+	}
+	return result, nil
+}
+
+var (
+	_ types.InvoiceRefunder = (*SocialPayAdapter)(nil)
+	_ types.InvoiceCanceler = (*SocialPayAdapter)(nil)
+)
+
+func (a *SocialPayAdapter) RefundInvoice(input types.RefundInput) (*types.RefundResult, error) {
+	return a.RefundInvoiceContext(context.Background(), input)
+}
+
+// RefundInvoiceContext reverses the payment made for the invoice.
+func (a *SocialPayAdapter) RefundInvoiceContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeSocial, types.OperationRefund)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypeSocial, types.OperationRefund, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeSocial, types.OperationRefund, func() (*socialpay.InvoiceResponse, error) {
+		return a.client.CancelPayment(socialpay.InvoiceInput{
+			Invoice: input.UID,
+			Amount:  amount,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.ResponseCode != socialPayApproved {
+		return nil, declined(types.PaymentTypeSocial, types.OperationRefund, res.ResponseCode, res.ResponseDescription)
+	}
+	return &types.RefundResult{
+		IsRefunded: true,
+		RefundID:   res.ApprovalCode,
+		Msg:        res.ResponseDescription,
+		Raw:        res,
+	}, nil
+}
+
+func (a *SocialPayAdapter) CancelInvoice(input types.CancelInput) (*types.CancelResult, error) {
+	return a.CancelInvoiceContext(context.Background(), input)
+}
+
+func (a *SocialPayAdapter) CancelInvoiceContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeSocial, types.OperationCancel)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypeSocial, types.OperationCancel, input.Amount)
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := a.client.CheckInvoice(socialpay.InvoiceInput{
-		Invoice: input.UID,
-		Amount:  input.Amount,
+	res, err := callContext(ctx, types.PaymentTypeSocial, types.OperationCancel, func() (*socialpay.CommonResponse, error) {
+		return a.client.CancelInvoice(socialpay.InvoiceInput{
+			Invoice: input.UID,
+			Amount:  amount,
+		})
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
-		IsPaid: res.ResponseCode == "00",
+	return &types.CancelResult{
+		IsCancelled: true,
+		Msg:         res.Description,
+		Raw:         res,
 	}, nil
 }
@@ -0,0 +1,77 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/techpartners-asia/golomt-api-go/socialpay"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeSocialPay answers CheckInvoice and CancelPayment with a 3000 MNT
+// transaction carrying code.
+type fakeSocialPay struct {
+	socialpay.SocialPay
+
+	code string
+}
+
+func (s fakeSocialPay) CheckInvoice(input socialpay.InvoiceInput) (*socialpay.InvoiceResponse, error) {
+	return &socialpay.InvoiceResponse{ApprovalCode: "A1B2C3", Amount: 3000, ResponseCode: s.code, ResponseDescription: "desc", Invoice: input.Invoice}, nil
+}
+
+func (s fakeSocialPay) CancelPayment(input socialpay.InvoiceInput) (*socialpay.InvoiceResponse, error) {
+	return s.CheckInvoice(input)
+}
+
+func TestSocialPayCheckStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want types.PaymentStatus
+	}{
+		{name: "approved", code: "00", want: types.PaymentStatusPaid},
+		{name: "not paid", code: "", want: types.PaymentStatusPending},
+		{name: "declined", code: "05", want: types.PaymentStatusPending},
+		{name: "insufficient funds", code: "51", want: types.PaymentStatusPending},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &SocialPayAdapter{client: fakeSocialPay{code: tt.code}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1", Amount: types.MNT(3000)})
+			wantStatus(t, result, err, tt.want, types.MNT(3000))
+			if result.IsPaid && (len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "A1B2C3") {
+				t.Errorf("transactions = %v, want [A1B2C3]", result.TransactionIDs)
+			}
+		})
+	}
+}
+
+func TestSocialPayRefund(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		declined bool
+	}{
+		{name: "refunded", code: "00"},
+		{name: "declined", code: "12", declined: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &SocialPayAdapter{client: fakeSocialPay{code: tt.code}}
+			result, err := adapter.RefundInvoiceContext(context.Background(), types.RefundInput{UID: "order-1", Amount: types.MNT(3000)})
+			if !tt.declined {
+				if err != nil || !result.IsRefunded || result.RefundID != "A1B2C3" {
+					t.Fatalf("refund = %+v, %v, want refunded A1B2C3", result, err)
+				}
+				return
+			}
+			var perr *types.ProviderError
+			if !errors.As(err, &perr) || perr.Code != tt.code || perr.Message != "desc" {
+				t.Fatalf("err = %v, want a *ProviderError with code %s and message desc", err, tt.code)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package sdkAdapters
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
@@ -11,21 +12,50 @@ import (
 // StorePayAdapter implements PaymentProvider for StorePay.
 type StorePayAdapter struct {
 	client storepay.Storepay
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*StorePayAdapter)(nil)
+	_ types.ProviderDescriber = (*StorePayAdapter)(nil)
+)
+
 func NewStorePayAdapter(input types.StorePayAdapter) *StorePayAdapter {
-	return &StorePayAdapter{client: storepay.New(input.AppUserName, input.AppPassword, input.Username, input.Password, input.AuthUrl, input.BaseUrl, input.StoreId, input.CallbackUrl)}
+	return &StorePayAdapter{
+		client:  storepay.New(input.AppUserName, input.AppPassword, input.Username, input.Password, input.AuthUrl, input.BaseUrl, input.StoreId, input.CallbackUrl),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *StorePayAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:          types.PaymentTypeStorePay,
+		DisplayName:   "StorePay",
+		MinAmount:     types.NewMoney(1, types.CurrencyMNT),
+		RequiresPhone: true,
+	})
 }
 
 func (a *StorePayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *StorePayAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("storepay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeStorePay, types.OperationCreate)
+	}
+
+	amount, err := decimalAmount(types.PaymentTypeStorePay, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := a.client.Loan(storepay.StorepayLoanInput{
-		Amount:       input.Amount,
-		MobileNumber: input.Phone,
-		Description:  input.Note,
+	res, err := callContext(ctx, types.PaymentTypeStorePay, types.OperationCreate, func() (int64, error) {
+		return a.client.Loan(storepay.StorepayLoanInput{
+			Amount:       amount,
+			MobileNumber: input.Phone,
+			Description:  input.Note,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -39,16 +69,30 @@ func (a *StorePayAdapter) CreateInvoice(input types.InvoiceInput) (*types.Invoic
 }
 
 func (a *StorePayAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *StorePayAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("storepay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeStorePay, types.OperationCheck)
 	}
 
-	res, err := a.client.LoanCheck(input.UID)
+	res, err := callContext(ctx, types.PaymentTypeStorePay, types.OperationCheck, func() (bool, error) {
+		return a.client.LoanCheck(input.UID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.CheckInvoiceResult{
+	// LoanCheck only reports whether the loan was confirmed, not its amount.
+	result := &types.CheckInvoiceResult{
 		IsPaid: res,
-	}, nil
+		Status: types.PaymentStatusPending,
+		Raw:    res,
+	}
+	if res {
+		result.Status = types.PaymentStatusPaid
+		result.TransactionIDs = []string{input.UID}
+	}
+	return result, nil
 }
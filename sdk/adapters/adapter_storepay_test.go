@@ -0,0 +1,50 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	storepay "github.com/techpartners-asia/storepay-go"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeStorePay answers LoanCheck with confirmed and err.
+type fakeStorePay struct {
+	storepay.Storepay
+
+	confirmed bool
+	err       error
+}
+
+func (s fakeStorePay) LoanCheck(id string) (bool, error) {
+	return s.confirmed, s.err
+}
+
+func TestStorePayCheckStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		confirmed bool
+		want      types.PaymentStatus
+	}{
+		{name: "waiting", want: types.PaymentStatusPending},
+		{name: "confirmed", confirmed: true, want: types.PaymentStatusPaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &StorePayAdapter{client: fakeStorePay{confirmed: tt.confirmed}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"})
+			// LoanCheck does not report the loan amount.
+			wantStatus(t, result, err, tt.want, types.Money{})
+			if result.IsPaid && (len(result.TransactionIDs) != 1 || result.TransactionIDs[0] != "order-1") {
+				t.Errorf("transactions = %v, want [order-1]", result.TransactionIDs)
+			}
+		})
+	}
+
+	adapter := &StorePayAdapter{client: fakeStorePay{err: errors.New("loan not found")}}
+	if _, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"}); err == nil {
+		t.Fatal("check of an unknown loan succeeded")
+	}
+}
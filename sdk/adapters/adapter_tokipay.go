@@ -1,7 +1,8 @@
 package sdkAdapters
 
 import (
-	"fmt"
+	"context"
+	"strings"
 
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 
@@ -11,22 +12,52 @@ import (
 // TokiPayAdapter implements PaymentProvider for Tokipay.
 type TokiPayAdapter struct {
 	client tokipay.Tokipay
+	display
 }
 
+var (
+	_ types.PaymentProvider   = (*TokiPayAdapter)(nil)
+	_ types.ProviderDescriber = (*TokiPayAdapter)(nil)
+)
+
 func NewTokiPayAdapter(input types.TokipayAdapter) *TokiPayAdapter {
-	return &TokiPayAdapter{client: tokipay.New(input.Endpoint, input.APIKey, input.IMAPIKey, input.Authorization, input.MerchantID, input.SuccessURL, input.FailureURL, input.AppSchemaIOS)}
+	return &TokiPayAdapter{
+		client:  tokipay.New(input.Endpoint, input.APIKey, input.IMAPIKey, input.Authorization, input.MerchantID, input.SuccessURL, input.FailureURL, input.AppSchemaIOS),
+		display: newDisplay(input.LogoURL, input.MaxAmount),
+	}
+}
+
+func (a *TokiPayAdapter) Info() types.ProviderInfo {
+	return a.describe(types.ProviderInfo{
+		Type:          types.PaymentTypeTokipay,
+		DisplayName:   "Tokipay",
+		MinAmount:     types.MNT(1),
+		WholeAmount:   true,
+		RequiresPhone: true,
+	})
 }
 
 func (a *TokiPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return a.CreateInvoiceContext(context.Background(), input)
+}
+
+func (a *TokiPayAdapter) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("tokipay adapter not configured")
-	}
-	res, err := a.client.PaymentSentUser(tokipay.TokipayPaymentInput{
-		OrderId:     input.UID,
-		Amount:      int64(input.Amount),
-		PhoneNo:     input.Phone,
-		CountryCode: "+976",
-		Notes:       input.Note,
+		return nil, notConfigured(types.PaymentTypeTokipay, types.OperationCreate)
+	}
+	amount, err := wholeAmount(types.PaymentTypeTokipay, types.OperationCreate, input.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeTokipay, types.OperationCreate, func() (tokipay.TokipayPaymentResponse, error) {
+		return a.client.PaymentSentUser(tokipay.TokipayPaymentInput{
+			OrderId:     input.UID,
+			Amount:      amount,
+			PhoneNo:     input.Phone,
+			CountryCode: "+976",
+			Notes:       input.Note,
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -39,16 +70,65 @@ func (a *TokiPayAdapter) CreateInvoice(input types.InvoiceInput) (*types.Invoice
 }
 
 func (a *TokiPayAdapter) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return a.CheckInvoiceContext(context.Background(), input)
+}
+
+func (a *TokiPayAdapter) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
 	if a == nil || a.client == nil {
-		return nil, fmt.Errorf("tokipay adapter not configured")
+		return nil, notConfigured(types.PaymentTypeTokipay, types.OperationCheck)
 	}
 
-	res, err := a.client.PaymentStatus(input.UID)
+	res, err := callContext(ctx, types.PaymentTypeTokipay, types.OperationCheck, func() (tokipay.TokipayPaymentStatusResponse, error) {
+		return a.client.PaymentStatus(input.UID)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	status := types.PaymentStatusPending
+	switch strings.ToUpper(res.Data.Status) {
+	case "COMPLETED":
+		status = types.PaymentStatusPaid
+	case "FAILED", "DECLINED":
+		status = types.PaymentStatusFailed
+	case "EXPIRED":
+		status = types.PaymentStatusExpired
+	case "CANCELLED", "CANCELED":
+		status = types.PaymentStatusCancelled
+	case "REFUNDED":
+		status = types.PaymentStatusRefunded
+	}
+
+	// Tokipay only reports the status, so PaidAmount is left zero.
 	return &types.CheckInvoiceResult{
-		IsPaid: res.Data.Status == "COMPLETED",
+		IsPaid: status == types.PaymentStatusPaid,
+		Status: status,
+		Msg:    res.Message,
+		Raw:    res,
+	}, nil
+}
+
+var _ types.InvoiceCanceler = (*TokiPayAdapter)(nil)
+
+func (a *TokiPayAdapter) CancelInvoice(input types.CancelInput) (*types.CancelResult, error) {
+	return a.CancelInvoiceContext(context.Background(), input)
+}
+
+func (a *TokiPayAdapter) CancelInvoiceContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	if a == nil || a.client == nil {
+		return nil, notConfigured(types.PaymentTypeTokipay, types.OperationCancel)
+	}
+
+	res, err := callContext(ctx, types.PaymentTypeTokipay, types.OperationCancel, func() (tokipay.TokipayPaymentStatusResponse, error) {
+		return a.client.PaymentCancel(input.UID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.CancelResult{
+		IsCancelled: true,
+		Msg:         res.Message,
+		Raw:         res,
 	}, nil
 }
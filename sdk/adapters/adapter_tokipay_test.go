@@ -0,0 +1,47 @@
+package sdkAdapters
+
+import (
+	"context"
+	"testing"
+
+	tokipay "github.com/techpartners-asia/tokipay-go"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeTokipay answers PaymentStatus with status.
+type fakeTokipay struct {
+	tokipay.Tokipay
+
+	status string
+}
+
+func (p fakeTokipay) PaymentStatus(requestID string) (tokipay.TokipayPaymentStatusResponse, error) {
+	return tokipay.TokipayPaymentStatusResponse{StatusCode: 200, Message: "success", Data: tokipay.TokipayPaymentStatusDataResponse{Status: p.status}}, nil
+}
+
+func TestTokipayCheckStatus(t *testing.T) {
+	tests := []struct {
+		status string
+		want   types.PaymentStatus
+	}{
+		{status: "PENDING", want: types.PaymentStatusPending},
+		{status: "REQUESTED", want: types.PaymentStatusPending},
+		{status: "COMPLETED", want: types.PaymentStatusPaid},
+		{status: "completed", want: types.PaymentStatusPaid},
+		{status: "FAILED", want: types.PaymentStatusFailed},
+		{status: "DECLINED", want: types.PaymentStatusFailed},
+		{status: "EXPIRED", want: types.PaymentStatusExpired},
+		{status: "CANCELLED", want: types.PaymentStatusCancelled},
+		{status: "CANCELED", want: types.PaymentStatusCancelled},
+		{status: "REFUNDED", want: types.PaymentStatusRefunded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			adapter := &TokiPayAdapter{client: fakeTokipay{status: tt.status}}
+			result, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "order-1"})
+			// Tokipay does not report the paid amount.
+			wantStatus(t, result, err, tt.want, types.Money{})
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package sdkAdapters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// callContext runs a blocking provider call and returns early when ctx is
+// cancelled or its deadline passes. Errors are wrapped in a
+// *types.ProviderError for paymentType and op.
+//
+// The provider libraries do not accept a context, so cancellation does not
+// stop the request: an abandoned call keeps running in the background until
+// the underlying HTTP client gives up, and its result is discarded. An
+// abandoned Create may therefore still open the invoice. The error returned
+// for an abandoned call matches types.ErrOutcomeUnknown as well as ctx.Err().
+//
+// Some libraries panic on HTTP failures instead of returning them: qpay-go
+// dereferences the nil response of a failed request and golomt-api-go
+// asserts a string error body. A recovered panic is reported as
+// types.ErrProviderUnavailable matching types.ErrOutcomeUnknown, since the
+// request may have reached the provider.
+func callContext[T any](ctx context.Context, paymentType types.PaymentType, op types.Operation, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		// Nothing was sent yet, so the outcome is known.
+		return zero, providerError(paymentType, op, err)
+	}
+
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			// A panic in a provider library must not take the process down
+			// once the caller has stopped waiting for it.
+			if r := recover(); r != nil {
+				done <- result{err: &types.ProviderError{
+					Type:      paymentType,
+					Operation: op,
+					Kind:      types.ErrProviderUnavailable,
+					Err:       fmt.Errorf("%w: provider library panic: %v", types.ErrOutcomeUnknown, r),
+				}}
+			}
+		}()
+		val, err := fn()
+		done <- result{val: val, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return zero, providerError(paymentType, op, fmt.Errorf("%w: %w", types.ErrOutcomeUnknown, ctx.Err()))
+	case res := <-done:
+		if res.err != nil {
+			return zero, providerError(paymentType, op, res.err)
+		}
+		return res.val, nil
+	}
+}
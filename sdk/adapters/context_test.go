@@ -0,0 +1,92 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	qpay_v2 "github.com/techpartners-asia/qpay-go/qpay_v2"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// panickingQPay fails CheckPayment the way qpay-go does when the HTTP
+// request fails: by reading the response it did not get.
+type panickingQPay struct {
+	qpay_v2.QPay
+}
+
+func (panickingQPay) CheckPayment(invoiceID string, pageLimit, pageNumber int64) (qpay_v2.QpayPaymentCheckResponse, qpay_v2.QPay, error) {
+	var res *http.Response
+	_ = res.StatusCode
+	return qpay_v2.QpayPaymentCheckResponse{}, nil, nil
+}
+
+func TestCallContext(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		fn          func() (string, error)
+		wantKind    error
+		wantUnknown bool
+	}{
+		{name: "success", ctx: context.Background(), fn: func() (string, error) { return "ok", nil }},
+		{name: "provider error", ctx: context.Background(), fn: func() (string, error) { return "", errors.New("rejected") }},
+		{name: "cancelled before the call", ctx: cancelled, fn: func() (string, error) { panic("called") }},
+		{
+			name:        "panic",
+			ctx:         context.Background(),
+			fn:          func() (string, error) { var m map[string]int; m["a"]++; return "", nil },
+			wantKind:    types.ErrProviderUnavailable,
+			wantUnknown: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := callContext(tt.ctx, types.PaymentTypeQPay, types.OperationCreate, tt.fn)
+			if tt.name == "success" {
+				if err != nil || val != "ok" {
+					t.Fatalf("callContext = %q, %v, want ok", val, err)
+				}
+				return
+			}
+			var perr *types.ProviderError
+			if !errors.As(err, &perr) || perr.Type != types.PaymentTypeQPay || perr.Operation != types.OperationCreate {
+				t.Fatalf("err = %v, want a qpay create *ProviderError", err)
+			}
+			if tt.wantKind != nil && !errors.Is(err, tt.wantKind) {
+				t.Errorf("err = %v, want %v", err, tt.wantKind)
+			}
+			if got := errors.Is(err, types.ErrOutcomeUnknown); got != tt.wantUnknown {
+				t.Errorf("errors.Is(%v, ErrOutcomeUnknown) = %v, want %v", err, got, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestCallContextAbandoned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+
+	_, err := callContext(ctx, types.PaymentTypeQPay, types.OperationCreate, func() (string, error) {
+		cancel()
+		<-release
+		return "late", nil
+	})
+	if !errors.Is(err, types.ErrOutcomeUnknown) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want ErrOutcomeUnknown and context.Canceled", err)
+	}
+}
+
+func TestQPayCheckPanic(t *testing.T) {
+	adapter := &QPayAdapter{client: panickingQPay{}}
+	_, err := adapter.CheckInvoiceContext(context.Background(), types.CheckInvoiceInput{UID: "inv", Amount: types.MNT(100)})
+	if !errors.Is(err, types.ErrProviderUnavailable) || !errors.Is(err, types.ErrOutcomeUnknown) {
+		t.Fatalf("err = %v, want ErrProviderUnavailable with ErrOutcomeUnknown", err)
+	}
+}
@@ -0,0 +1,24 @@
+package sdkAdapters
+
+import "github.com/techpartners-asia/payments-gateway/sdk/types"
+
+// display holds the picker details an adapter config may set.
+type display struct {
+	logoURL   string
+	maxAmount types.Money
+}
+
+func newDisplay(logoURL string, maxAmount int64) display {
+	d := display{logoURL: logoURL}
+	if maxAmount > 0 {
+		d.maxAmount = types.MNT(maxAmount)
+	}
+	return d
+}
+
+// describe fills the configured LogoURL and MaxAmount into info.
+func (d display) describe(info types.ProviderInfo) types.ProviderInfo {
+	info.LogoURL = d.logoURL
+	info.MaxAmount = d.maxAmount
+	return info
+}
@@ -0,0 +1,43 @@
+package sdkAdapters
+
+import (
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestInfoDisplay(t *testing.T) {
+	const logo = "https://cdn.example.mn/logo.png"
+	adapters := map[types.PaymentType]types.ProviderDescriber{
+		types.PaymentTypeQPay:     NewQPayAdapter(types.QpayAdapter{Username: "u", Password: "p", Endpoint: "https://merchant.qpay.mn/v2", InvoiceCode: "c", MerchantID: "m", LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeTokipay:  NewTokiPayAdapter(types.TokipayAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeMonpay:   NewMonpayAdapter(types.MonpayAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeGolomt:   NewGolomtAdapter(types.GolomtAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeSocial:   NewSocialPayAdapter(types.SocialPayAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeStorePay: NewStorePayAdapter(types.StorePayAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypePocket:   NewPocketAdapter(types.PocketAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeSimple:   NewSimpleAdapter(types.SimpleAdapter{LogoURL: logo, MaxAmount: 5000000}),
+		types.PaymentTypeBalc:     NewBalcCreditAdapter(types.BalcAdapter{LogoURL: logo, MaxAmount: 5000000}),
+	}
+	for paymentType, adapter := range adapters {
+		t.Run(string(paymentType), func(t *testing.T) {
+			info := adapter.Info()
+			if info.Type != paymentType {
+				t.Errorf("Type = %s, want %s", info.Type, paymentType)
+			}
+			if info.LogoURL != logo {
+				t.Errorf("LogoURL = %q, want %q", info.LogoURL, logo)
+			}
+			if info.MaxAmount.Cmp(types.MNT(5000000)) != 0 {
+				t.Errorf("MaxAmount = %s, want 5000000 MNT", info.MaxAmount)
+			}
+		})
+	}
+}
+
+func TestInfoDisplayUnset(t *testing.T) {
+	info := NewGolomtAdapter(types.GolomtAdapter{}).Info()
+	if info.LogoURL != "" || !info.MaxAmount.IsZero() {
+		t.Errorf("LogoURL = %q, MaxAmount = %s, want both unset", info.LogoURL, info.MaxAmount)
+	}
+}
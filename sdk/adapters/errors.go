@@ -0,0 +1,111 @@
+package sdkAdapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func notConfigured(paymentType types.PaymentType, op types.Operation) error {
+	return &types.ProviderError{Type: paymentType, Operation: op, Kind: types.ErrNotConfigured}
+}
+
+func invalidInput(paymentType types.PaymentType, op types.Operation, format string, args ...any) error {
+	return &types.ProviderError{Type: paymentType, Operation: op, Kind: types.ErrInvalidInput, Message: fmt.Sprintf(format, args...)}
+}
+
+// declined reports a request the provider answered with a failure code
+// rather than an error, keeping its code and description.
+func declined(paymentType types.PaymentType, op types.Operation, code, message string) error {
+	return &types.ProviderError{Type: paymentType, Operation: op, Code: code, Message: message}
+}
+
+// providerError wraps an error returned while calling a provider, keeping
+// errors that are already classified as they are.
+func providerError(paymentType types.PaymentType, op types.Operation, err error) error {
+	var perr *types.ProviderError
+	if errors.As(err, &perr) {
+		return err
+	}
+	return &types.ProviderError{
+		Type:      paymentType,
+		Operation: op,
+		Kind:      classifyError(err),
+		Code:      providerCode(err),
+		Err:       err,
+	}
+}
+
+// statusCoder is implemented by errors that carry the HTTP status code of
+// the provider response.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// classifyError maps typed transport errors and HTTP status codes onto an
+// error kind. Provider messages are not standardised, so they are never
+// matched as text; errors without a recognised type are left unclassified.
+func classifyError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return types.ErrProviderUnavailable
+	}
+
+	switch status := httpStatus(err); {
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		return types.ErrAuthFailed
+	case status == http.StatusConflict:
+		return types.ErrDuplicate
+	case status == http.StatusTooManyRequests, status >= 500 && status <= 599:
+		return types.ErrProviderUnavailable
+	}
+	return nil
+}
+
+// httpStatus returns the HTTP status code carried by err, either through
+// StatusCode() or as a numeric statusCode field of a JSON error body such as
+// Tokipay's {"statusCode":401,"error":"Unauthorized"}. It returns 0 when
+// there is none.
+func httpStatus(err error) int {
+	var coder statusCoder
+	if errors.As(err, &coder) {
+		return coder.StatusCode()
+	}
+	var body map[string]any
+	if json.Unmarshal([]byte(err.Error()), &body) != nil {
+		return 0
+	}
+	for _, key := range []string{"statusCode", "status_code", "status"} {
+		if v, ok := body[key].(float64); ok && v >= 100 && v <= 599 && v == float64(int(v)) {
+			return int(v)
+		}
+	}
+	return 0
+}
+
+// providerCode extracts an error code when the provider returned a JSON
+// error body, e.g. QPay's {"error":"INVOICE_NOT_FOUND","message":"..."}.
+func providerCode(err error) string {
+	var body map[string]any
+	if json.Unmarshal([]byte(err.Error()), &body) != nil {
+		return ""
+	}
+	for _, key := range []string{"error", "code", "errorCode", "error_code", "status"} {
+		switch v := body[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%v", v)
+		}
+	}
+	return ""
+}
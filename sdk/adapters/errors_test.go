@@ -0,0 +1,66 @@
+package sdkAdapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+type statusError int
+
+func (e statusError) Error() string   { return fmt.Sprintf("http status %d", int(e)) }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "deadline", err: context.DeadlineExceeded, want: types.ErrProviderUnavailable},
+		{name: "dial", err: &url.Error{Op: "Post", URL: "https://provider", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, want: types.ErrProviderUnavailable},
+		{name: "eof", err: fmt.Errorf("read body: %w", io.EOF), want: types.ErrProviderUnavailable},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, want: types.ErrProviderUnavailable},
+		{name: "401", err: statusError(401), want: types.ErrAuthFailed},
+		{name: "403", err: fmt.Errorf("wrapped: %w", statusError(403)), want: types.ErrAuthFailed},
+		{name: "409", err: statusError(409), want: types.ErrDuplicate},
+		{name: "429", err: statusError(429), want: types.ErrProviderUnavailable},
+		{name: "503", err: statusError(503), want: types.ErrProviderUnavailable},
+		{name: "400", err: statusError(400), want: nil},
+		{name: "json statusCode", err: errors.New(`{"statusCode":401,"error":"Unauthorized"}`), want: types.ErrAuthFailed},
+		{name: "json status 502", err: errors.New(`{"status":502}`), want: types.ErrProviderUnavailable},
+		{name: "json status text", err: errors.New(`{"status":"PAID"}`), want: nil},
+
+		// Messages are never matched as text.
+		{name: "word containing eof", err: errors.New("Geoffrey's invoice is invalid"), want: nil},
+		{name: "already paid", err: errors.New("invoice already paid"), want: nil},
+		{name: "unauthorized text", err: errors.New("unauthorized"), want: nil},
+		{name: "canceled", err: context.Canceled, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.err); got != tt.want {
+				t.Fatalf("classifyError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderErrorKeepsClassified(t *testing.T) {
+	classified := invalidInput(types.PaymentTypeQPay, types.OperationCreate, "bad amount")
+	if got := providerError(types.PaymentTypeQPay, types.OperationCreate, fmt.Errorf("wrapped: %w", classified)); !errors.Is(got, types.ErrInvalidInput) {
+		t.Fatalf("providerError reclassified %v", got)
+	}
+
+	err := providerError(types.PaymentTypeQPay, types.OperationCheck, errors.New(`{"error":"INVOICE_NOT_FOUND","message":"not found"}`))
+	var perr *types.ProviderError
+	if !errors.As(err, &perr) || perr.Code != "INVOICE_NOT_FOUND" {
+		t.Fatalf("providerError code = %+v, want INVOICE_NOT_FOUND", perr)
+	}
+}
@@ -0,0 +1,43 @@
+package sdkAdapters
+
+import (
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// checkAmount rejects amounts no provider in this package can charge: all of
+// them bill in MNT only.
+func checkAmount(paymentType types.PaymentType, op types.Operation, amount types.Money) error {
+	if amount.CurrencyCode() != types.CurrencyMNT {
+		return invalidInput(paymentType, op, "only %s amounts are supported, got %s", types.CurrencyMNT, amount.CurrencyCode())
+	}
+	if !amount.IsPositive() {
+		return invalidInput(paymentType, op, "amount must be positive, got %s", amount)
+	}
+	return nil
+}
+
+// wholeAmount converts amount for providers whose API only takes whole tugriks.
+func wholeAmount(paymentType types.PaymentType, op types.Operation, amount types.Money) (int64, error) {
+	if err := checkAmount(paymentType, op, amount); err != nil {
+		return 0, err
+	}
+	whole, err := amount.WholeUnits()
+	if err != nil {
+		return 0, invalidInput(paymentType, op, "only whole %s amounts are accepted: %v", types.CurrencyMNT, err)
+	}
+	return whole, nil
+}
+
+// decimalAmount converts amount for providers whose API takes a float that
+// is sent with two decimal places.
+func decimalAmount(paymentType types.PaymentType, op types.Operation, amount types.Money) (float64, error) {
+	if err := checkAmount(paymentType, op, amount); err != nil {
+		return 0, err
+	}
+	return amount.Float64(), nil
+}
+
+// mnt converts a provider-reported float amount.
+func mnt(amount float64) types.Money {
+	return types.MoneyFromFloat(amount, types.CurrencyMNT)
+}
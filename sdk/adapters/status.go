@@ -0,0 +1,37 @@
+package sdkAdapters
+
+import (
+	"strings"
+	"time"
+)
+
+var providerTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// parseProviderTime parses the timestamp formats returned by provider APIs.
+// It returns nil for empty or unrecognised values rather than guessing.
+func parseProviderTime(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	for _, layout := range providerTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// unixMillis converts a millisecond epoch timestamp; zero means unset.
+func unixMillis(ms int64) *time.Time {
+	if ms <= 0 {
+		return nil
+	}
+	t := time.UnixMilli(ms)
+	return &t
+}
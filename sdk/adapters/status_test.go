@@ -0,0 +1,22 @@
+package sdkAdapters
+
+import (
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// wantStatus fails t unless result reports status, and, for a paid result,
+// paidAmount.
+func wantStatus(t *testing.T, result *types.CheckInvoiceResult, err error, status types.PaymentStatus, paidAmount types.Money) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Status != status || result.IsPaid != (status == types.PaymentStatusPaid) {
+		t.Fatalf("status = %s, paid = %v, want %s", result.Status, result.IsPaid, status)
+	}
+	if result.IsPaid && result.PaidAmount != paidAmount {
+		t.Errorf("paid amount = %s, want %s", result.PaidAmount, paidAmount)
+	}
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import "github.com/techpartners-asia/payments-gateway/sdk/types"
+
+// Re-exports of sdk/types so callers only need to import this package.
+type (
+	PaymentType     = types.PaymentType
+	PaymentProvider = types.PaymentProvider
+	PaymentStatus   = types.PaymentStatus
+	Money           = types.Money
+
+	InvoiceInput       = types.InvoiceInput
+	InvoiceResult      = types.InvoiceResult
+	Deeplink           = types.Deeplink
+	CheckInvoiceInput  = types.CheckInvoiceInput
+	CheckInvoiceResult = types.CheckInvoiceResult
+	RefundInput        = types.RefundInput
+	RefundResult       = types.RefundResult
+	CancelInput        = types.CancelInput
+	CancelResult       = types.CancelResult
+
+	Operation         = types.Operation
+	NotSupportedError = types.NotSupportedError
+	ProviderError     = types.ProviderError
+
+	ErrorCode = types.ErrorCode
+	Language  = types.Language
+
+	ValidationError = types.ValidationError
+	FieldError      = types.FieldError
+
+	QPayConfig      = types.QpayAdapter
+	TokipayConfig   = types.TokipayAdapter
+	StorePayConfig  = types.StorePayAdapter
+	SocialPayConfig = types.SocialPayAdapter
+	SimpleConfig    = types.SimpleAdapter
+	PocketConfig    = types.PocketAdapter
+	MonpayConfig    = types.MonpayAdapter
+	GolomtConfig    = types.GolomtAdapter
+	BalcConfig      = types.BalcAdapter
+)
+
+var (
+	ErrNotSupported        = types.ErrNotSupported
+	ErrNotConfigured       = types.ErrNotConfigured
+	ErrInsufficientLimit   = types.ErrInsufficientLimit
+	ErrInvalidInput        = types.ErrInvalidInput
+	ErrProviderUnavailable = types.ErrProviderUnavailable
+	ErrAuthFailed          = types.ErrAuthFailed
+	ErrDuplicate           = types.ErrDuplicate
+	ErrIdempotencyConflict = types.ErrIdempotencyConflict
+	ErrCircuitOpen         = types.ErrCircuitOpen
+	ErrOutcomeUnknown      = types.ErrOutcomeUnknown
+)
+
+const (
+	OperationCreate = types.OperationCreate
+	OperationCheck  = types.OperationCheck
+	OperationRefund = types.OperationRefund
+	OperationCancel = types.OperationCancel
+)
+
+const (
+	LanguageMN = types.LanguageMN
+	LanguageEN = types.LanguageEN
+)
+
+const (
+	PaymentTypeQPay     = types.PaymentTypeQPay
+	PaymentTypeTokipay  = types.PaymentTypeTokipay
+	PaymentTypeMonpay   = types.PaymentTypeMonpay
+	PaymentTypeGolomt   = types.PaymentTypeGolomt
+	PaymentTypeSocial   = types.PaymentTypeSocial
+	PaymentTypeStorePay = types.PaymentTypeStorePay
+	PaymentTypePocket   = types.PaymentTypePocket
+	PaymentTypeSimple   = types.PaymentTypeSimple
+	PaymentTypeBalc     = types.PaymentTypeBalc
+)
+
+// MNT returns a whole tugrik amount.
+func MNT(tugrik int64) Money {
+	return types.MNT(tugrik)
+}
+
+// NewMoney returns minor units of currency.
+func NewMoney(minor int64, currency string) Money {
+	return types.NewMoney(minor, currency)
+}
+
+// ParseMoney parses an exact decimal amount such as "15000.50".
+func ParseMoney(value, currency string) (Money, error) {
+	return types.ParseMoney(value, currency)
+}
+
+// ErrorCodeOf returns the stable code of err; see types.ErrorCodeOf.
+func ErrorCodeOf(err error) ErrorCode {
+	return types.ErrorCodeOf(err)
+}
+
+// UserMessage returns a localized message for err that is safe to show to
+// payers; see types.UserMessage.
+func UserMessage(err error, lang Language) string {
+	return types.UserMessage(err, lang)
+}
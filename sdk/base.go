@@ -1,105 +1,324 @@
 package sdk
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
 
-	sdkAdapters "github.com/techpartners-asia/payments-gateway/sdk/adapters"
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
 	"github.com/techpartners-asia/payments-gateway/sdk/types"
 )
 
 type Input struct {
-	Qpay      types.QpayAdapter
-	TokiPay   types.TokipayAdapter
-	StorePay  types.StorePayAdapter
-	SocialPay types.SocialPayAdapter
-	Simple    types.SimpleAdapter
-	Pocket    types.PocketAdapter
-	MonPay    types.MonpayAdapter
-	Golomt    types.GolomtAdapter
-	Balc      types.BalcAdapter
+	Qpay      types.QpayAdapter      `json:"qpay" yaml:"qpay"`
+	TokiPay   types.TokipayAdapter   `json:"tokipay" yaml:"tokipay"`
+	StorePay  types.StorePayAdapter  `json:"storepay" yaml:"storepay"`
+	SocialPay types.SocialPayAdapter `json:"socialpay" yaml:"socialpay"`
+	Simple    types.SimpleAdapter    `json:"simple" yaml:"simple"`
+	Pocket    types.PocketAdapter    `json:"pocket" yaml:"pocket"`
+	MonPay    types.MonpayAdapter    `json:"monpay" yaml:"monpay"`
+	Golomt    types.GolomtAdapter    `json:"golomt" yaml:"golomt"`
+	Balc      types.BalcAdapter      `json:"balc" yaml:"balc"`
+
+	// Providers are registered after the built-in adapters, so an entry here
+	// replaces the built-in adapter for the same payment type.
+	Providers map[types.PaymentType]types.PaymentProvider `json:"-" yaml:"-"`
+
+	// IdempotencyStore, when set, makes Create return the stored result for a
+	// (Type, UID) that was already created instead of calling the provider
+	// again. Inputs with an empty UID are not deduplicated.
+	IdempotencyStore IdempotencyStore `json:"-" yaml:"-"`
+
+	// Store, when set, records every created invoice and the status changes
+	// seen by Check. A store failure is returned together with the provider
+	// result, which is still valid.
+	Store store.Store `json:"-" yaml:"-"`
+
+	// Retry overrides the retry policy per operation. Operations without an
+	// entry use DefaultRetryPolicy, except Refund and Cancel, which are not
+	// retried unless configured here. Create is only retried when the
+	// request never reached the provider, or the provider reports
+	// DedupesCreate; set Retryable for Create only if the provider dedupes
+	// invoices by UID.
+	Retry map[types.Operation]RetryPolicy `json:"-" yaml:"-"`
+
+	// Breaker configures the per-provider circuit breaker, which is enabled
+	// with default settings unless Breaker.Disabled is set.
+	Breaker BreakerPolicy `json:"-" yaml:"-"`
+
+	// Logger, when set, records every provider request with its operation,
+	// provider, UID, latency and outcome. Credentials from the provider
+	// configs and secret payload fields (tokens, passwords, card data) are
+	// masked; request and response payloads are only logged at Debug.
+	Logger *slog.Logger `json:"-" yaml:"-"`
+
+	// Interceptors wrap every operation of every provider, outermost first.
+	// SDK.Use appends more after construction.
+	Interceptors []Interceptor `json:"-" yaml:"-"`
+
+	// ProviderInfo overrides what AvailableProviders reports per payment type,
+	// e.g. a LogoURL on your CDN or a MaxAmount agreed with the provider.
+	// Only non-empty fields are applied.
+	ProviderInfo map[types.PaymentType]types.ProviderInfo `json:"provider_info,omitempty" yaml:"provider_info,omitempty"`
 }
 
 type SDK interface {
 	Create(input types.InvoiceInput) (*types.InvoiceResult, error)
 	Check(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error)
+
+	// CreateContext and CheckContext return an error wrapping ctx.Err() as soon
+	// as ctx is cancelled or its deadline passes, so callers can bound each
+	// provider call. Cancellation does not stop a request already sent: a
+	// cancelled Create may still open the invoice at the provider. Such errors
+	// match types.ErrOutcomeUnknown and must be treated as an unknown outcome,
+	// not a failure; check the invoice before creating it again.
+	CreateContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error)
+	CheckContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error)
+
+	// Refund and Cancel return a *types.NotSupportedError (matching
+	// types.ErrNotSupported) when the provider cannot perform the operation.
+	// Refund may return a result together with an error when only part of
+	// the refund went through.
+	Refund(input types.RefundInput) (*types.RefundResult, error)
+	Cancel(input types.CancelInput) (*types.CancelResult, error)
+	RefundContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error)
+	CancelContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error)
+
+	// Register adds or replaces the provider serving paymentType.
+	Register(paymentType types.PaymentType, provider types.PaymentProvider)
+	// Use appends interceptors wrapping every operation of every provider.
+	Use(interceptors ...Interceptor)
+	// Providers lists the payment types that currently have a provider.
+	Providers() []types.PaymentType
+	// Health reports the circuit breaker state of every registered provider,
+	// so unavailable payment methods can be hidden.
+	Health() []ProviderHealth
+	// AvailableProviders describes every configured provider for checkout
+	// payment-method pickers, in payment type order.
+	AvailableProviders() []types.ProviderInfo
+	// ValidateInvoice checks input against the requirements of the provider
+	// for input.Type without calling it. Create runs the same checks first.
+	ValidateInvoice(input types.InvoiceInput) error
 }
 
 type sdk struct {
-	input Input
+	input    Input
+	registry *Registry
+	breakers *breakers // nil when disabled
+	logger   *slog.Logger
+	redactor *types.Redactor
 
-	*sdkAdapters.BalcCreditAdapter
-	*sdkAdapters.QPayAdapter
-	*sdkAdapters.TokiPayAdapter
-	*sdkAdapters.StorePayAdapter
-	*sdkAdapters.SocialPayAdapter
-	*sdkAdapters.SimpleAdapter
-	*sdkAdapters.PocketAdapter
-	*sdkAdapters.MonpayAdapter
-	*sdkAdapters.GolomtAdapter
+	mu           sync.RWMutex
+	unconfigured map[types.PaymentType]bool // built-in adapters registered by NewSDK without credentials
+	interceptors []Interceptor
 }
 
+// New wires every built-in adapter from input without validating it.
+//
+// Deprecated: Use NewGateway, which fails fast on incomplete provider
+// configs, or NewSDK, which New is an alias of.
 func New(input Input) SDK {
-	return &sdk{
-		input:             input,
-		QPayAdapter:       sdkAdapters.NewQPayAdapter(input.Qpay),
-		TokiPayAdapter:    sdkAdapters.NewTokiPayAdapter(input.TokiPay),
-		StorePayAdapter:   sdkAdapters.NewStorePayAdapter(input.StorePay),
-		SocialPayAdapter:  sdkAdapters.NewSocialPayAdapter(input.SocialPay),
-		SimpleAdapter:     sdkAdapters.NewSimpleAdapter(input.Simple),
-		PocketAdapter:     sdkAdapters.NewPocketAdapter(input.Pocket),
-		MonpayAdapter:     sdkAdapters.NewMonpayAdapter(input.MonPay),
-		GolomtAdapter:     sdkAdapters.NewGolomtAdapter(input.Golomt),
-		BalcCreditAdapter: sdkAdapters.NewBalcCreditAdapter(input.Balc),
+	return NewSDK(input)
+}
+
+// NewSDK wires every built-in adapter from input without validating it.
+// Prefer NewGateway, which fails fast on incomplete provider configs.
+func NewSDK(input Input) SDK {
+	return newSDK(input, false)
+}
+
+// newSDK registers the built-in adapters followed by input.Providers. With
+// onlyConfigured set, adapters whose config section is empty are skipped.
+func newSDK(input Input, onlyConfigured bool) *sdk {
+	registry := NewRegistry()
+
+	unconfigured := make(map[types.PaymentType]bool)
+	for _, provider := range input.providerConfigs() {
+		if !provider.configured {
+			if onlyConfigured {
+				continue
+			}
+			unconfigured[provider.paymentType] = true
+		}
+		registry.Register(provider.paymentType, provider.build())
+	}
+
+	for paymentType, provider := range input.Providers {
+		registry.Register(paymentType, provider)
+		delete(unconfigured, paymentType)
+	}
+
+	s := &sdk{
+		input:        input,
+		registry:     registry,
+		unconfigured: unconfigured,
+		interceptors: append([]Interceptor(nil), input.Interceptors...),
+	}
+	if !input.Breaker.Disabled {
+		s.breakers = newBreakers(input.Breaker)
+	}
+	if input.Logger != nil {
+		s.logger = input.Logger
+		s.redactor = types.NewRedactor(types.SecretValues(input)...)
+	}
+	return s
+}
+
+func (s *sdk) Register(paymentType types.PaymentType, provider types.PaymentProvider) {
+	s.registry.Register(paymentType, provider)
+	if s.breakers != nil {
+		s.breakers.reset(paymentType)
+	}
+
+	s.mu.Lock()
+	delete(s.unconfigured, paymentType)
+	s.mu.Unlock()
+}
+
+func (s *sdk) Providers() []types.PaymentType {
+	return s.registry.Types()
+}
+
+func (s *sdk) Health() []ProviderHealth {
+	now := time.Now()
+	paymentTypes := s.registry.Types()
+	health := make([]ProviderHealth, 0, len(paymentTypes))
+	for _, paymentType := range paymentTypes {
+		if s.breakers == nil {
+			health = append(health, ProviderHealth{Type: paymentType, State: CircuitClosed, Available: true})
+			continue
+		}
+		health = append(health, s.breakers.health(paymentType, now))
+	}
+	return health
+}
+
+func (s *sdk) provider(paymentType types.PaymentType, op types.Operation) (types.PaymentProvider, error) {
+	provider, ok := s.registry.Provider(paymentType)
+	if !ok {
+		return nil, &types.ProviderError{Type: paymentType, Operation: op, Kind: types.ErrNotConfigured}
 	}
+	return provider, nil
 }
 
 func (s *sdk) Create(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return s.CreateContext(context.Background(), input)
+}
 
-	switch input.Type {
-	case types.PaymentTypeQPay:
-		return s.QPayAdapter.CreateInvoice(input)
-	case types.PaymentTypeTokipay:
-		return s.TokiPayAdapter.CreateInvoice(input)
-	case types.PaymentTypeStorePay:
-		return s.StorePayAdapter.CreateInvoice(input)
-	case types.PaymentTypeSocial:
-		return s.SocialPayAdapter.CreateInvoice(input)
-	case types.PaymentTypeSimple:
-		return s.SimpleAdapter.CreateInvoice(input)
-	case types.PaymentTypePocket:
-		return s.PocketAdapter.CreateInvoice(input)
-	case types.PaymentTypeMonpay:
-		return s.MonpayAdapter.CreateInvoice(input)
-	case types.PaymentTypeGolomt:
-		return s.GolomtAdapter.CreateInvoice(input)
-	case types.PaymentTypeBalc:
-		return s.BalcCreditAdapter.CreateInvoice(input)
-	default:
-		return nil, fmt.Errorf("unsupported payment type: %s", input.Type)
+func (s *sdk) CreateContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
+	input, err := resolveUID(input)
+	if err != nil {
+		return nil, err
 	}
+	return intercept(ctx, s, types.OperationCreate, input.Type, input.UID, input, s.create)
+}
+
+func (s *sdk) create(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
+	provider, err := s.provider(input.Type, types.OperationCreate)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateInvoice(provider, input); err != nil {
+		return nil, err
+	}
+	call := func() (*types.InvoiceResult, error) {
+		return providerCall(ctx, s, input.Type, types.OperationCreate, input.UID, input, func() (*types.InvoiceResult, error) {
+			return provider.CreateInvoiceContext(ctx, input)
+		})
+	}
+	// An error matching ErrOutcomeUnknown is passed through untouched: it is
+	// not retried and, with an IdempotencyStore, keeps the UID reserved.
+	record := func(call func() (*types.InvoiceResult, error)) (*types.InvoiceResult, error) {
+		result, err := call()
+		if err != nil {
+			return nil, err
+		}
+		return result, s.recordCreate(ctx, input, result)
+	}
+
+	create := func() (*types.InvoiceResult, error) {
+		return record(func() (*types.InvoiceResult, error) {
+			return retryCreate(ctx, s, provider, call)
+		})
+	}
+	if s.input.IdempotencyStore == nil || input.UID == "" {
+		return create()
+	}
+	return createIdempotent(ctx, s.input.IdempotencyStore, input, create)
 }
 
 func (s *sdk) Check(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
-	switch input.Type {
-	case types.PaymentTypeQPay:
-		return s.QPayAdapter.CheckInvoice(input)
-	case types.PaymentTypeTokipay:
-		return s.TokiPayAdapter.CheckInvoice(input)
-	case types.PaymentTypeStorePay:
-		return s.StorePayAdapter.CheckInvoice(input)
-	case types.PaymentTypeSocial:
-		return s.SocialPayAdapter.CheckInvoice(input)
-	case types.PaymentTypeSimple:
-		return s.SimpleAdapter.CheckInvoice(input)
-	case types.PaymentTypePocket:
-		return s.PocketAdapter.CheckInvoice(input)
-	case types.PaymentTypeMonpay:
-		return s.MonpayAdapter.CheckInvoice(input)
-	case types.PaymentTypeGolomt:
-		return s.GolomtAdapter.CheckInvoice(input)
-	case types.PaymentTypeBalc:
-		return s.BalcCreditAdapter.CheckInvoice(input)
-	default:
-		return nil, fmt.Errorf("unsupported payment type: %s", input.Type)
+	return s.CheckContext(context.Background(), input)
+}
+
+func (s *sdk) CheckContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return intercept(ctx, s, types.OperationCheck, input.Type, input.UID, input, s.check)
+}
+
+func (s *sdk) check(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	provider, err := s.provider(input.Type, types.OperationCheck)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCheck(provider, input); err != nil {
+		return nil, err
+	}
+	result, err := retryCall(ctx, s, types.OperationCheck, func() (*types.CheckInvoiceResult, error) {
+		return providerCall(ctx, s, input.Type, types.OperationCheck, input.UID, input, func() (*types.CheckInvoiceResult, error) {
+			return provider.CheckInvoiceContext(ctx, input)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, s.recordCheck(ctx, input, result)
+}
+
+func (s *sdk) Refund(input types.RefundInput) (*types.RefundResult, error) {
+	return s.RefundContext(context.Background(), input)
+}
+
+func (s *sdk) RefundContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	return intercept(ctx, s, types.OperationRefund, input.Type, input.UID, input, s.refund)
+}
+
+func (s *sdk) refund(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	provider, err := s.provider(input.Type, types.OperationRefund)
+	if err != nil {
+		return nil, err
+	}
+	refunder, ok := provider.(types.InvoiceRefunder)
+	if !ok {
+		return nil, &types.NotSupportedError{Type: input.Type, Operation: types.OperationRefund}
+	}
+	return retryCall(ctx, s, types.OperationRefund, func() (*types.RefundResult, error) {
+		return providerCall(ctx, s, input.Type, types.OperationRefund, input.UID, input, func() (*types.RefundResult, error) {
+			return refunder.RefundInvoiceContext(ctx, input)
+		})
+	})
+}
+
+func (s *sdk) Cancel(input types.CancelInput) (*types.CancelResult, error) {
+	return s.CancelContext(context.Background(), input)
+}
+
+func (s *sdk) CancelContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	return intercept(ctx, s, types.OperationCancel, input.Type, input.UID, input, s.cancel)
+}
+
+func (s *sdk) cancel(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	provider, err := s.provider(input.Type, types.OperationCancel)
+	if err != nil {
+		return nil, err
+	}
+	canceler, ok := provider.(types.InvoiceCanceler)
+	if !ok {
+		return nil, &types.NotSupportedError{Type: input.Type, Operation: types.OperationCancel}
 	}
+	return retryCall(ctx, s, types.OperationCancel, func() (*types.CancelResult, error) {
+		return providerCall(ctx, s, input.Type, types.OperationCancel, input.UID, input, func() (*types.CancelResult, error) {
+			return canceler.CancelInvoiceContext(ctx, input)
+		})
+	})
 }
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestNewKeepsReturningSDK(t *testing.T) {
+	provider := &fakeprovider.Provider{}
+	var s SDK = New(Input{Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider}})
+
+	if _, err := s.Create(types.InvoiceInput{Type: "fake", UID: "order-1", Amount: types.MNT(100)}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(provider.Creates()) != 1 {
+		t.Fatalf("provider saw %d creates, want 1", len(provider.Creates()))
+	}
+}
+
+func TestCreatePaymentUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   types.InvoiceInput
+		wantUID string
+		wantErr bool
+	}{
+		{name: "UID", input: types.InvoiceInput{UID: "order-1"}, wantUID: "order-1"},
+		{name: "PaymentUID", input: types.InvoiceInput{PaymentUID: "order-1"}, wantUID: "order-1"},
+		{name: "both equal", input: types.InvoiceInput{UID: "order-1", PaymentUID: "order-1"}, wantUID: "order-1"},
+		{name: "both differ", input: types.InvoiceInput{UID: "order-1", PaymentUID: "order-2"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeprovider.Provider{}
+			gw := NewGatewayFromSDK(NewSDK(Input{Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider}}))
+
+			input := tt.input
+			input.Type = "fake"
+			input.Amount = types.MNT(100)
+			_, err := gw.CreateInvoice(input)
+			if tt.wantErr {
+				if !errors.Is(err, types.ErrInvalidInput) || len(provider.Creates()) != 0 {
+					t.Fatalf("err = %v, creates = %d, want ErrInvalidInput before calling the provider", err, len(provider.Creates()))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateInvoice: %v", err)
+			}
+			if got := provider.Creates()[0].UID; got != tt.wantUID {
+				t.Fatalf("provider got UID %q, want %q", got, tt.wantUID)
+			}
+		})
+	}
+}
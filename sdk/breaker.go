@@ -0,0 +1,198 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// BreakerPolicy configures the circuit breaker kept for each payment type.
+// After FailureThreshold consecutive failures the circuit opens and calls
+// fail fast with ErrProviderUnavailable wrapping ErrCircuitOpen. Once
+// OpenTimeout has passed a single probe call is let through: success closes
+// the circuit, failure opens it again.
+type BreakerPolicy struct {
+	Disabled         bool
+	FailureThreshold int           // default 5
+	OpenTimeout      time.Duration // default 30s
+	// IsFailure reports whether err counts against the provider; nil counts
+	// provider outages (ErrProviderUnavailable) only, not rejected input.
+	IsFailure func(err error) bool
+}
+
+const (
+	defaultBreakerThreshold   = 5
+	defaultBreakerOpenTimeout = 30 * time.Second
+)
+
+// CircuitState is the state of a provider's circuit breaker.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// ProviderHealth is the breaker view of one registered provider.
+type ProviderHealth struct {
+	Type                types.PaymentType `json:"type"`
+	State               CircuitState      `json:"state"`
+	Available           bool              `json:"available"` // calls are currently let through
+	ConsecutiveFailures int               `json:"consecutive_failures"`
+	LastError           string            `json:"last_error,omitempty"`
+	LastFailure         *time.Time        `json:"last_failure,omitempty"`
+	LastSuccess         *time.Time        `json:"last_success,omitempty"`
+}
+
+type circuit struct {
+	mu          sync.Mutex
+	state       CircuitState
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	lastErr     error
+	lastFailure time.Time
+	lastSuccess time.Time
+}
+
+type breakers struct {
+	policy BreakerPolicy
+
+	mu       sync.Mutex
+	circuits map[types.PaymentType]*circuit
+}
+
+func newBreakers(policy BreakerPolicy) *breakers {
+	if policy.FailureThreshold <= 0 {
+		policy.FailureThreshold = defaultBreakerThreshold
+	}
+	if policy.OpenTimeout <= 0 {
+		policy.OpenTimeout = defaultBreakerOpenTimeout
+	}
+	if policy.IsFailure == nil {
+		policy.IsFailure = func(err error) bool {
+			return errors.Is(err, types.ErrProviderUnavailable) && !errors.Is(err, context.Canceled)
+		}
+	}
+	return &breakers{policy: policy, circuits: make(map[types.PaymentType]*circuit)}
+}
+
+func (b *breakers) circuit(paymentType types.PaymentType) *circuit {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[paymentType]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[paymentType] = c
+	}
+	return c
+}
+
+// reset forgets the history of paymentType, e.g. after its provider is replaced.
+func (b *breakers) reset(paymentType types.PaymentType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.circuits, paymentType)
+}
+
+// allow reports whether a call may proceed and whether it is the probe of a
+// half-open circuit.
+func (b *breakers) allow(c *circuit, now time.Time) (ok, probe bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case CircuitOpen:
+		if now.Sub(c.openedAt) < b.policy.OpenTimeout {
+			return false, false
+		}
+		c.state = CircuitHalfOpen
+		fallthrough
+	case CircuitHalfOpen:
+		if c.probing {
+			return false, false
+		}
+		c.probing = true
+		return true, true
+	}
+	return true, false
+}
+
+func (b *breakers) record(c *circuit, probe bool, err error, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if probe {
+		c.probing = false
+	}
+	if err == nil {
+		c.state, c.failures, c.lastSuccess = CircuitClosed, 0, now
+		return
+	}
+	if !b.policy.IsFailure(err) {
+		// Errors such as rejected input say nothing about the provider's
+		// health; a half-open circuit waits for the next probe.
+		return
+	}
+
+	c.failures++
+	c.lastErr, c.lastFailure = err, now
+	if probe || c.failures >= b.policy.FailureThreshold {
+		c.state, c.openedAt = CircuitOpen, now
+	}
+}
+
+func (b *breakers) health(paymentType types.PaymentType, now time.Time) ProviderHealth {
+	c := b.circuit(paymentType)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h := ProviderHealth{
+		Type:                paymentType,
+		State:               c.state,
+		Available:           c.state == CircuitClosed || (c.state == CircuitHalfOpen && !c.probing),
+		ConsecutiveFailures: c.failures,
+	}
+	if c.state == CircuitOpen && now.Sub(c.openedAt) >= b.policy.OpenTimeout {
+		h.State, h.Available = CircuitHalfOpen, true
+	}
+	if c.lastErr != nil {
+		h.LastError = c.lastErr.Error()
+	}
+	if lastFailure := c.lastFailure; !lastFailure.IsZero() {
+		h.LastFailure = &lastFailure
+	}
+	if lastSuccess := c.lastSuccess; !lastSuccess.IsZero() {
+		h.LastSuccess = &lastSuccess
+	}
+	return h
+}
+
+// guardCall runs fn through the circuit breaker of paymentType.
+func guardCall[T any](s *sdk, paymentType types.PaymentType, op types.Operation, fn func() (T, error)) (T, error) {
+	if s.breakers == nil {
+		return fn()
+	}
+
+	c := s.breakers.circuit(paymentType)
+	ok, probe := s.breakers.allow(c, time.Now())
+	if !ok {
+		var zero T
+		return zero, &types.ProviderError{
+			Type:      paymentType,
+			Operation: op,
+			Kind:      types.ErrProviderUnavailable,
+			Err:       types.ErrCircuitOpen,
+		}
+	}
+
+	val, err := fn()
+	s.breakers.record(c, probe, err, time.Now())
+	return val, err
+}
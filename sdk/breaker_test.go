@@ -0,0 +1,194 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestBreaker(t *testing.T) {
+	outage := &types.ProviderError{Type: "fake", Kind: types.ErrProviderUnavailable}
+	invalid := &types.ProviderError{Type: "fake", Kind: types.ErrInvalidInput}
+
+	// step is one call at offset after the start: allowed is what allow
+	// must report, err is recorded when the call is let through.
+	type step struct {
+		offset    time.Duration
+		err       error
+		allowed   bool
+		wantState CircuitState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "opens after threshold",
+			steps: []step{
+				{err: outage, allowed: true, wantState: CircuitClosed},
+				{err: outage, allowed: true, wantState: CircuitClosed},
+				{err: outage, allowed: true, wantState: CircuitOpen},
+				{offset: time.Second, allowed: false, wantState: CircuitOpen},
+			},
+		},
+		{
+			name: "success resets failures",
+			steps: []step{
+				{err: outage, allowed: true, wantState: CircuitClosed},
+				{err: outage, allowed: true, wantState: CircuitClosed},
+				{allowed: true, wantState: CircuitClosed},
+				{err: outage, allowed: true, wantState: CircuitClosed},
+				{err: outage, allowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "rejected input is not a failure",
+			steps: []step{
+				{err: invalid, allowed: true, wantState: CircuitClosed},
+				{err: invalid, allowed: true, wantState: CircuitClosed},
+				{err: invalid, allowed: true, wantState: CircuitClosed},
+				{err: invalid, allowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "probe success closes",
+			steps: []step{
+				{err: outage, allowed: true},
+				{err: outage, allowed: true},
+				{err: outage, allowed: true, wantState: CircuitOpen},
+				{offset: time.Minute, allowed: true, wantState: CircuitClosed},
+				{offset: time.Minute, err: outage, allowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "probe failure reopens",
+			steps: []step{
+				{err: outage, allowed: true},
+				{err: outage, allowed: true},
+				{err: outage, allowed: true, wantState: CircuitOpen},
+				{offset: time.Minute, err: outage, allowed: true, wantState: CircuitOpen},
+				{offset: time.Minute + time.Second, allowed: false, wantState: CircuitOpen},
+				{offset: 2 * time.Minute, allowed: true, wantState: CircuitClosed},
+			},
+		},
+		{
+			name: "rejected probe stays half open",
+			steps: []step{
+				{err: outage, allowed: true},
+				{err: outage, allowed: true},
+				{err: outage, allowed: true, wantState: CircuitOpen},
+				{offset: time.Minute, err: invalid, allowed: true, wantState: CircuitHalfOpen},
+				{offset: time.Minute, allowed: true, wantState: CircuitClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newBreakers(BreakerPolicy{FailureThreshold: 3, OpenTimeout: 30 * time.Second})
+			c := b.circuit("fake")
+			start := time.Now()
+
+			for i, s := range tt.steps {
+				now := start.Add(s.offset)
+				ok, probe := b.allow(c, now)
+				if ok != s.allowed {
+					t.Fatalf("step %d: allowed = %v, want %v", i, ok, s.allowed)
+				}
+				if ok {
+					b.record(c, probe, s.err, now)
+				}
+				if s.wantState != "" && c.state != s.wantState {
+					t.Fatalf("step %d: state = %s, want %s", i, c.state, s.wantState)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakerSingleProbe(t *testing.T) {
+	b := newBreakers(BreakerPolicy{FailureThreshold: 1, OpenTimeout: time.Second})
+	c := b.circuit("fake")
+	start := time.Now()
+	b.record(c, false, &types.ProviderError{Kind: types.ErrProviderUnavailable}, start)
+
+	later := start.Add(time.Minute)
+	if h := b.health("fake", later); h.State != CircuitHalfOpen || !h.Available {
+		t.Fatalf("health before probe = %s available %v, want half_open available", h.State, h.Available)
+	}
+	if ok, probe := b.allow(c, later); !ok || !probe {
+		t.Fatalf("first call after timeout: ok %v probe %v, want probe", ok, probe)
+	}
+	if ok, _ := b.allow(c, later); ok {
+		t.Fatal("second call let through while the probe is in flight")
+	}
+	if h := b.health("fake", later); h.Available || h.ConsecutiveFailures != 1 || h.LastFailure == nil {
+		t.Fatalf("health during probe = %+v", h)
+	}
+}
+
+func TestBreakerFailsFast(t *testing.T) {
+	provider := &fakeprovider.Provider{Answers: []fakeprovider.Answer{
+		{Err: &types.ProviderError{Type: "fake", Kind: types.ErrProviderUnavailable}},
+	}}
+	s := NewSDK(Input{
+		Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider},
+		Retry:     map[types.Operation]RetryPolicy{types.OperationCheck: {MaxAttempts: 1}},
+		Breaker:   BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Hour},
+	})
+
+	check := types.CheckInvoiceInput{Type: "fake", UID: "order-1"}
+	for range 2 {
+		if _, err := s.Check(check); errors.Is(err, types.ErrCircuitOpen) {
+			t.Fatalf("circuit open before the threshold: %v", err)
+		}
+	}
+	_, err := s.Check(check)
+	if !errors.Is(err, types.ErrCircuitOpen) || !errors.Is(err, types.ErrProviderUnavailable) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if len(provider.Checks()) != 2 {
+		t.Fatalf("provider saw %d checks, want 2", len(provider.Checks()))
+	}
+	for _, h := range s.Health() {
+		if h.Type == "fake" && (h.State != CircuitOpen || h.Available || h.ConsecutiveFailures != 2) {
+			t.Fatalf("Health = %+v, want an open circuit after 2 failures", h)
+		}
+	}
+}
+
+// golomt-api-go panics on HTTP error responses instead of returning them;
+// the recovered panic must still count as an outage.
+func TestBreakerOpensOnLibraryPanic(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	s := NewSDK(Input{
+		Golomt:  types.GolomtAdapter{BaseURL: server.URL, Secret: "secret", BearerToken: "token"},
+		Retry:   map[types.Operation]RetryPolicy{types.OperationCheck: {MaxAttempts: 1}},
+		Breaker: BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Hour},
+	})
+
+	check := types.CheckInvoiceInput{Type: types.PaymentTypeGolomt, UID: "order-1"}
+	for range 2 {
+		_, err := s.Check(check)
+		if !errors.Is(err, types.ErrProviderUnavailable) || errors.Is(err, types.ErrCircuitOpen) {
+			t.Fatalf("err = %v, want ErrProviderUnavailable from the provider", err)
+		}
+	}
+	if _, err := s.Check(check); !errors.Is(err, types.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("provider saw %d requests, want 2", got)
+	}
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	sdkAdapters "github.com/techpartners-asia/payments-gateway/sdk/adapters"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Config is the provider configuration accepted by NewGateway. Providers whose
+// section is left empty are not wired into the Gateway.
+type Config = Input
+
+// providerConfig describes how a built-in provider is checked and built from Input.
+type providerConfig struct {
+	paymentType types.PaymentType
+	field       string // Input field holding the provider section
+	configured  bool   // whether any field of the section was filled in
+	validate    func() error
+	build       func() types.PaymentProvider
+}
+
+func (input Input) providerConfigs() []providerConfig {
+	return []providerConfig{
+		{
+			paymentType: types.PaymentTypeQPay,
+			field:       "Qpay",
+			configured:  input.Qpay != (types.QpayAdapter{}),
+			validate:    input.Qpay.Validate,
+			build: func() types.PaymentProvider {
+				// NewQPayAdapter returns nil when credentials are missing; avoid
+				// registering a typed nil so QPay is not listed as available.
+				if adapter := sdkAdapters.NewQPayAdapter(input.Qpay); adapter != nil {
+					return adapter
+				}
+				return nil
+			},
+		},
+		{
+			paymentType: types.PaymentTypeTokipay,
+			field:       "TokiPay",
+			configured:  input.TokiPay != (types.TokipayAdapter{}),
+			validate:    input.TokiPay.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewTokiPayAdapter(input.TokiPay) },
+		},
+		{
+			paymentType: types.PaymentTypeStorePay,
+			field:       "StorePay",
+			configured:  input.StorePay != (types.StorePayAdapter{}),
+			validate:    input.StorePay.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewStorePayAdapter(input.StorePay) },
+		},
+		{
+			paymentType: types.PaymentTypeSocial,
+			field:       "SocialPay",
+			configured:  input.SocialPay != (types.SocialPayAdapter{}),
+			validate:    input.SocialPay.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewSocialPayAdapter(input.SocialPay) },
+		},
+		{
+			paymentType: types.PaymentTypeSimple,
+			field:       "Simple",
+			configured:  input.Simple != (types.SimpleAdapter{}),
+			validate:    input.Simple.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewSimpleAdapter(input.Simple) },
+		},
+		{
+			paymentType: types.PaymentTypePocket,
+			field:       "Pocket",
+			configured:  input.Pocket != (types.PocketAdapter{}),
+			validate:    input.Pocket.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewPocketAdapter(input.Pocket) },
+		},
+		{
+			paymentType: types.PaymentTypeMonpay,
+			field:       "MonPay",
+			configured:  input.MonPay != (types.MonpayAdapter{}),
+			validate:    input.MonPay.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewMonpayAdapter(input.MonPay) },
+		},
+		{
+			paymentType: types.PaymentTypeGolomt,
+			field:       "Golomt",
+			configured:  input.Golomt != (types.GolomtAdapter{}),
+			validate:    input.Golomt.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewGolomtAdapter(input.Golomt) },
+		},
+		{
+			paymentType: types.PaymentTypeBalc,
+			field:       "Balc",
+			configured:  input.Balc != (types.BalcAdapter{}),
+			validate:    input.Balc.Validate,
+			build:       func() types.PaymentProvider { return sdkAdapters.NewBalcCreditAdapter(input.Balc) },
+		},
+	}
+}
+
+// Validate checks every provider section that was filled in and returns a
+// *types.ValidationError listing all invalid fields, prefixed with the
+// section name (e.g. "Qpay.Endpoint").
+func (input Input) Validate() error {
+	var v types.Validator
+	configured := 0
+	for _, provider := range input.providerConfigs() {
+		if !provider.configured {
+			continue
+		}
+		configured++
+		v.Merge(provider.field, provider.validate())
+	}
+	for paymentType, provider := range input.Providers {
+		configured++
+		if provider == nil {
+			v.Add("Providers."+string(paymentType), "is nil")
+		}
+	}
+	if configured == 0 {
+		v.Add("Input", "no payment providers configured")
+	}
+	return v.Err()
+}
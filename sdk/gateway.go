@@ -0,0 +1,64 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Gateway is the high-level entrypoint returned by NewGateway. It embeds SDK,
+// so Create, Check, Register and Providers remain available on it.
+type Gateway struct {
+	SDK
+}
+
+// NewGateway validates cfg and builds a Gateway over every provider it
+// configures. A provider section that is partly filled in is reported as an
+// error rather than producing an adapter that fails at the first payment.
+func NewGateway(cfg Config) (*Gateway, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return NewGatewayFromSDK(newSDK(cfg, true)), nil
+}
+
+// NewGatewayFromSDK wraps an already constructed SDK.
+func NewGatewayFromSDK(s SDK) *Gateway {
+	return &Gateway{SDK: s}
+}
+
+// CreateInvoice routes input to the provider registered for input.Type.
+func (g *Gateway) CreateInvoice(input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return g.CreateContext(context.Background(), input)
+}
+
+func (g *Gateway) CreateInvoiceContext(ctx context.Context, input types.InvoiceInput) (*types.InvoiceResult, error) {
+	return g.CreateContext(ctx, input)
+}
+
+// CheckInvoice asks the provider registered for input.Type whether the invoice is paid.
+func (g *Gateway) CheckInvoice(input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return g.CheckContext(context.Background(), input)
+}
+
+func (g *Gateway) CheckInvoiceContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	return g.CheckContext(ctx, input)
+}
+
+// RefundInvoice refunds a paid invoice with the provider registered for input.Type.
+func (g *Gateway) RefundInvoice(input types.RefundInput) (*types.RefundResult, error) {
+	return g.RefundContext(context.Background(), input)
+}
+
+func (g *Gateway) RefundInvoiceContext(ctx context.Context, input types.RefundInput) (*types.RefundResult, error) {
+	return g.RefundContext(ctx, input)
+}
+
+// CancelInvoice cancels an unpaid invoice with the provider registered for input.Type.
+func (g *Gateway) CancelInvoice(input types.CancelInput) (*types.CancelResult, error) {
+	return g.CancelContext(context.Background(), input)
+}
+
+func (g *Gateway) CancelInvoiceContext(ctx context.Context, input types.CancelInput) (*types.CancelResult, error) {
+	return g.CancelContext(ctx, input)
+}
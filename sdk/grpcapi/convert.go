@@ -0,0 +1,224 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/grpcapi/gatewayv1"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo attached to errors.
+const ErrorDomain = "payments-gateway"
+
+var paymentStatuses = map[types.PaymentStatus]gatewayv1.PaymentStatus{
+	types.PaymentStatusPending:       gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING,
+	types.PaymentStatusPaid:          gatewayv1.PaymentStatus_PAYMENT_STATUS_PAID,
+	types.PaymentStatusPartiallyPaid: gatewayv1.PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID,
+	types.PaymentStatusFailed:        gatewayv1.PaymentStatus_PAYMENT_STATUS_FAILED,
+	types.PaymentStatusExpired:       gatewayv1.PaymentStatus_PAYMENT_STATUS_EXPIRED,
+	types.PaymentStatusCancelled:     gatewayv1.PaymentStatus_PAYMENT_STATUS_CANCELLED,
+	types.PaymentStatusRefunded:      gatewayv1.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+}
+
+func paymentStatus(s types.PaymentStatus) gatewayv1.PaymentStatus {
+	return paymentStatuses[s]
+}
+
+func validateCreate(req *gatewayv1.CreateInvoiceRequest) error {
+	var v types.Validator
+	v.Required("type", req.GetType())
+	v.Required("uid", req.GetUid())
+	if req.GetAmount().GetMinor() <= 0 {
+		v.Add("amount", "must be positive")
+	}
+	return v.Err()
+}
+
+func invoiceInput(req *gatewayv1.CreateInvoiceRequest) types.InvoiceInput {
+	return types.InvoiceInput{
+		Amount:        money(req.GetAmount()),
+		UID:           req.GetUid(),
+		Phone:         req.GetPhone(),
+		CustomerID:    uint(req.GetCustomerId()),
+		Note:          req.GetNote(),
+		CallbackURL:   req.GetCallbackUrl(),
+		ReturnType:    req.GetReturnType(),
+		ExpireMinutes: int(req.GetExpireMinutes()),
+		Type:          types.PaymentType(req.GetType()),
+	}
+}
+
+func money(m *gatewayv1.Money) types.Money {
+	if m == nil {
+		return types.Money{}
+	}
+	return types.NewMoney(m.GetMinor(), m.GetCurrency())
+}
+
+func moneyProto(m types.Money) *gatewayv1.Money {
+	return &gatewayv1.Money{Minor: m.Minor, Currency: m.CurrencyCode()}
+}
+
+func createResponse(result *types.InvoiceResult) *gatewayv1.CreateInvoiceResponse {
+	res := &gatewayv1.CreateInvoiceResponse{
+		BankInvoiceId: result.BankInvoiceID,
+		BankQrCode:    result.BankQRCode,
+		IsPaid:        result.IsPaid,
+		Raw:           rawValue(result.Raw),
+	}
+	for _, link := range result.Deeplinks {
+		res.Deeplinks = append(res.Deeplinks, &gatewayv1.Deeplink{
+			Name:        link.Name,
+			Description: link.Description,
+			Link:        link.Link,
+			Logo:        link.Logo,
+		})
+	}
+	if result.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*result.ExpiresAt)
+	}
+	return res
+}
+
+func checkResponse(result *types.CheckInvoiceResult) *gatewayv1.CheckInvoiceResponse {
+	res := &gatewayv1.CheckInvoiceResponse{
+		IsPaid:         result.IsPaid,
+		Status:         paymentStatus(result.Status),
+		PaidAmount:     moneyProto(result.PaidAmount),
+		TransactionIds: result.TransactionIDs,
+		Msg:            result.Msg,
+		Raw:            rawValue(result.Raw),
+	}
+	if result.PaidAt != nil {
+		res.PaidAt = timestamppb.New(*result.PaidAt)
+	}
+	return res
+}
+
+func providerInfo(info types.ProviderInfo) *gatewayv1.ProviderInfo {
+	operations := make([]string, len(info.Operations))
+	for i, op := range info.Operations {
+		operations[i] = string(op)
+	}
+	return &gatewayv1.ProviderInfo{
+		Type:                string(info.Type),
+		DisplayName:         info.DisplayName,
+		LogoUrl:             info.LogoURL,
+		Operations:          operations,
+		MinAmount:           moneyProto(info.MinAmount),
+		MaxAmount:           moneyProto(info.MaxAmount),
+		WholeAmount:         info.WholeAmount,
+		MaxUidLength:        int32(info.MaxUIDLength),
+		RequiresPhone:       info.RequiresPhone,
+		RequiresCustomerId:  info.RequiresCustomerID,
+		RequiresCallbackUrl: info.RequiresCallbackURL,
+		Available:           info.Available,
+		CheckRequiresAmount: info.CheckRequiresAmount,
+	}
+}
+
+// rawValue converts a provider response to a protobuf Value through its JSON
+// form; responses that do not encode are left unset.
+func rawValue(raw any) *structpb.Value {
+	if raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var value structpb.Value
+	if err := value.UnmarshalJSON(data); err != nil {
+		return nil
+	}
+	return &value
+}
+
+// status converts an SDK error to a gRPC status carrying the error code as
+// ErrorInfo, a payer-safe LocalizedMessage and, for invalid input, the
+// invalid fields.
+func (s *Server) status(ctx context.Context, err error) error {
+	code := codeOf(err)
+	if code == codes.Internal || code == codes.Unknown {
+		s.input.Logger.ErrorContext(ctx, "request failed", "error", s.redactor.String(err.Error()))
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	lang := types.ParseLanguage(strings.Join(md.Get("accept-language"), ","))
+	st := status.New(code, s.redactor.String(err.Error()))
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: string(types.ErrorCodeOf(err)), Domain: ErrorDomain},
+		&errdetails.LocalizedMessage{Locale: string(lang), Message: types.UserMessage(err, lang)},
+	}
+	var validation *types.ValidationError
+	if errors.As(err, &validation) {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range validation.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldName(field.Field),
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if withDetails, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// codeOf maps an SDK error to a gRPC code.
+func codeOf(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, types.ErrInvalidInput), types.ErrorCodeOf(err) == types.ErrorCodeInvalidInput:
+		return codes.InvalidArgument
+	case errors.Is(err, types.ErrNotConfigured):
+		return codes.NotFound
+	case errors.Is(err, types.ErrNotSupported):
+		return codes.Unimplemented
+	case errors.Is(err, types.ErrDuplicate):
+		return codes.AlreadyExists
+	case errors.Is(err, types.ErrIdempotencyConflict), errors.Is(err, types.ErrInsufficientLimit):
+		return codes.FailedPrecondition
+	case errors.Is(err, types.ErrProviderUnavailable):
+		return codes.Unavailable
+	case errors.Is(err, types.ErrAuthFailed):
+		// Our provider credentials were rejected, not the caller's.
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// fieldName maps InvoiceInput field names in SDK validation errors to the
+// proto field names.
+func fieldName(field string) string {
+	switch field {
+	case "UID":
+		return "uid"
+	case "CustomerID":
+		return "customer_id"
+	case "CallbackURL":
+		return "callback_url"
+	case "ReturnType":
+		return "return_type"
+	case "ExpireMinutes":
+		return "expire_minutes"
+	}
+	return strings.ToLower(field)
+}
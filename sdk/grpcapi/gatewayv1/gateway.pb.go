@@ -0,0 +1,1100 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: sdk/grpcapi/gatewayv1/gateway.proto
+
+// Package payments.gateway.v1 exposes the payments SDK over gRPC.
+
+package gatewayv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PaymentStatus is the provider-independent state of an invoice.
+type PaymentStatus int32
+
+const (
+	PaymentStatus_PAYMENT_STATUS_UNSPECIFIED    PaymentStatus = 0
+	PaymentStatus_PAYMENT_STATUS_PENDING        PaymentStatus = 1
+	PaymentStatus_PAYMENT_STATUS_PAID           PaymentStatus = 2
+	PaymentStatus_PAYMENT_STATUS_PARTIALLY_PAID PaymentStatus = 3
+	PaymentStatus_PAYMENT_STATUS_FAILED         PaymentStatus = 4
+	PaymentStatus_PAYMENT_STATUS_EXPIRED        PaymentStatus = 5
+	PaymentStatus_PAYMENT_STATUS_CANCELLED      PaymentStatus = 6
+	PaymentStatus_PAYMENT_STATUS_REFUNDED       PaymentStatus = 7
+)
+
+// Enum value maps for PaymentStatus.
+var (
+	PaymentStatus_name = map[int32]string{
+		0: "PAYMENT_STATUS_UNSPECIFIED",
+		1: "PAYMENT_STATUS_PENDING",
+		2: "PAYMENT_STATUS_PAID",
+		3: "PAYMENT_STATUS_PARTIALLY_PAID",
+		4: "PAYMENT_STATUS_FAILED",
+		5: "PAYMENT_STATUS_EXPIRED",
+		6: "PAYMENT_STATUS_CANCELLED",
+		7: "PAYMENT_STATUS_REFUNDED",
+	}
+	PaymentStatus_value = map[string]int32{
+		"PAYMENT_STATUS_UNSPECIFIED":    0,
+		"PAYMENT_STATUS_PENDING":        1,
+		"PAYMENT_STATUS_PAID":           2,
+		"PAYMENT_STATUS_PARTIALLY_PAID": 3,
+		"PAYMENT_STATUS_FAILED":         4,
+		"PAYMENT_STATUS_EXPIRED":        5,
+		"PAYMENT_STATUS_CANCELLED":      6,
+		"PAYMENT_STATUS_REFUNDED":       7,
+	}
+)
+
+func (x PaymentStatus) Enum() *PaymentStatus {
+	p := new(PaymentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_enumTypes[0].Descriptor()
+}
+
+func (PaymentStatus) Type() protoreflect.EnumType {
+	return &file_sdk_grpcapi_gatewayv1_gateway_proto_enumTypes[0]
+}
+
+func (x PaymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentStatus.Descriptor instead.
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+// Money is an exact amount in minor units: 15000.50 MNT is
+// {minor: 1500050, currency: "MNT"}.
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Minor int64                  `protobuf:"varint,1,opt,name=minor,proto3" json:"minor,omitempty"`
+	// ISO 4217 code, empty means MNT.
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetMinor() int64 {
+	if x != nil {
+		return x.Minor
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Deeplink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Link          string                 `protobuf:"bytes,3,opt,name=link,proto3" json:"link,omitempty"`
+	Logo          string                 `protobuf:"bytes,4,opt,name=logo,proto3" json:"logo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deeplink) Reset() {
+	*x = Deeplink{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deeplink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deeplink) ProtoMessage() {}
+
+func (x *Deeplink) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deeplink.ProtoReflect.Descriptor instead.
+func (*Deeplink) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Deeplink) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Deeplink) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Deeplink) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Deeplink) GetLogo() string {
+	if x != nil {
+		return x.Logo
+	}
+	return ""
+}
+
+type CreateInvoiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Payment type: qpay, tokipay, monpay, golomt, socialpay, storepay, pocket,
+	// simple or balc.
+	Type   string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Uid    string `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Amount *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// 8-digit Mongolian number, required by Tokipay and StorePay.
+	Phone string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	// Required by Balc.
+	CustomerId uint64 `protobuf:"varint,5,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Note       string `protobuf:"bytes,6,opt,name=note,proto3" json:"note,omitempty"`
+	// Required by Golomt.
+	CallbackUrl string `protobuf:"bytes,7,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
+	// GET, POST or MOBILE; Golomt only.
+	ReturnType string `protobuf:"bytes,8,opt,name=return_type,json=returnType,proto3" json:"return_type,omitempty"`
+	// Invoice lifetime for providers that support it, 0 uses the provider default.
+	ExpireMinutes int32 `protobuf:"varint,9,opt,name=expire_minutes,json=expireMinutes,proto3" json:"expire_minutes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceRequest) Reset() {
+	*x = CreateInvoiceRequest{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceRequest) ProtoMessage() {}
+
+func (x *CreateInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateInvoiceRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *CreateInvoiceRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCustomerId() uint64 {
+	if x != nil {
+		return x.CustomerId
+	}
+	return 0
+}
+
+func (x *CreateInvoiceRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetReturnType() string {
+	if x != nil {
+		return x.ReturnType
+	}
+	return ""
+}
+
+func (x *CreateInvoiceRequest) GetExpireMinutes() int32 {
+	if x != nil {
+		return x.ExpireMinutes
+	}
+	return 0
+}
+
+type CreateInvoiceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pass as uid when checking the invoice.
+	BankInvoiceId string                 `protobuf:"bytes,1,opt,name=bank_invoice_id,json=bankInvoiceId,proto3" json:"bank_invoice_id,omitempty"`
+	BankQrCode    string                 `protobuf:"bytes,2,opt,name=bank_qr_code,json=bankQrCode,proto3" json:"bank_qr_code,omitempty"`
+	Deeplinks     []*Deeplink            `protobuf:"bytes,3,rep,name=deeplinks,proto3" json:"deeplinks,omitempty"`
+	IsPaid        bool                   `protobuf:"varint,4,opt,name=is_paid,json=isPaid,proto3" json:"is_paid,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Provider response as received.
+	Raw           *structpb.Value `protobuf:"bytes,6,opt,name=raw,proto3" json:"raw,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInvoiceResponse) Reset() {
+	*x = CreateInvoiceResponse{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInvoiceResponse) ProtoMessage() {}
+
+func (x *CreateInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateInvoiceResponse) GetBankInvoiceId() string {
+	if x != nil {
+		return x.BankInvoiceId
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetBankQrCode() string {
+	if x != nil {
+		return x.BankQrCode
+	}
+	return ""
+}
+
+func (x *CreateInvoiceResponse) GetDeeplinks() []*Deeplink {
+	if x != nil {
+		return x.Deeplinks
+	}
+	return nil
+}
+
+func (x *CreateInvoiceResponse) GetIsPaid() bool {
+	if x != nil {
+		return x.IsPaid
+	}
+	return false
+}
+
+func (x *CreateInvoiceResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CreateInvoiceResponse) GetRaw() *structpb.Value {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+type CheckInvoiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Uid   string                 `protobuf:"bytes,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Expected amount, required by providers whose check_requires_amount is
+	// set.
+	Amount        *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckInvoiceRequest) Reset() {
+	*x = CheckInvoiceRequest{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInvoiceRequest) ProtoMessage() {}
+
+func (x *CheckInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*CheckInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckInvoiceRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CheckInvoiceRequest) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *CheckInvoiceRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type CheckInvoiceResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IsPaid         bool                   `protobuf:"varint,1,opt,name=is_paid,json=isPaid,proto3" json:"is_paid,omitempty"`
+	Status         PaymentStatus          `protobuf:"varint,2,opt,name=status,proto3,enum=payments.gateway.v1.PaymentStatus" json:"status,omitempty"`
+	PaidAmount     *Money                 `protobuf:"bytes,3,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	PaidAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	TransactionIds []string               `protobuf:"bytes,5,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	Msg            string                 `protobuf:"bytes,6,opt,name=msg,proto3" json:"msg,omitempty"`
+	Raw            *structpb.Value        `protobuf:"bytes,7,opt,name=raw,proto3" json:"raw,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CheckInvoiceResponse) Reset() {
+	*x = CheckInvoiceResponse{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckInvoiceResponse) ProtoMessage() {}
+
+func (x *CheckInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*CheckInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CheckInvoiceResponse) GetIsPaid() bool {
+	if x != nil {
+		return x.IsPaid
+	}
+	return false
+}
+
+func (x *CheckInvoiceResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *CheckInvoiceResponse) GetPaidAmount() *Money {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return nil
+}
+
+func (x *CheckInvoiceResponse) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+func (x *CheckInvoiceResponse) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+func (x *CheckInvoiceResponse) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+func (x *CheckInvoiceResponse) GetRaw() *structpb.Value {
+	if x != nil {
+		return x.Raw
+	}
+	return nil
+}
+
+type WatchInvoiceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Check *CheckInvoiceRequest   `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	// When the invoice stops accepting payment, e.g. expires_at of
+	// CreateInvoiceResponse. Unset watches for the server's maximum duration.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInvoiceRequest) Reset() {
+	*x = WatchInvoiceRequest{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInvoiceRequest) ProtoMessage() {}
+
+func (x *WatchInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*WatchInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchInvoiceRequest) GetCheck() *CheckInvoiceRequest {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *WatchInvoiceRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type WatchInvoiceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status before this update, unspecified for the first one.
+	PreviousStatus PaymentStatus `protobuf:"varint,1,opt,name=previous_status,json=previousStatus,proto3,enum=payments.gateway.v1.PaymentStatus" json:"previous_status,omitempty"`
+	Status         PaymentStatus `protobuf:"varint,2,opt,name=status,proto3,enum=payments.gateway.v1.PaymentStatus" json:"status,omitempty"`
+	// The check that observed the status. For EXPIRED it is the check after
+	// expires_at that still found the invoice open.
+	Result        *CheckInvoiceResponse  `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	ObservedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchInvoiceResponse) Reset() {
+	*x = WatchInvoiceResponse{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchInvoiceResponse) ProtoMessage() {}
+
+func (x *WatchInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*WatchInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WatchInvoiceResponse) GetPreviousStatus() PaymentStatus {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *WatchInvoiceResponse) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_PAYMENT_STATUS_UNSPECIFIED
+}
+
+func (x *WatchInvoiceResponse) GetResult() *CheckInvoiceResponse {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *WatchInvoiceResponse) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+type ListProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersRequest) Reset() {
+	*x = ListProvidersRequest{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersRequest) ProtoMessage() {}
+
+func (x *ListProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{8}
+}
+
+type ListProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*ProviderInfo        `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProvidersResponse) Reset() {
+	*x = ListProvidersResponse{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProvidersResponse) ProtoMessage() {}
+
+func (x *ListProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProvidersResponse) GetProviders() []*ProviderInfo {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type ProviderInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Type        string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	DisplayName string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	LogoUrl     string                 `protobuf:"bytes,3,opt,name=logo_url,json=logoUrl,proto3" json:"logo_url,omitempty"`
+	// create, check, refund, cancel.
+	Operations []string `protobuf:"bytes,4,rep,name=operations,proto3" json:"operations,omitempty"`
+	MinAmount  *Money   `protobuf:"bytes,5,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	// Zero means no limit known.
+	MaxAmount           *Money `protobuf:"bytes,6,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	WholeAmount         bool   `protobuf:"varint,7,opt,name=whole_amount,json=wholeAmount,proto3" json:"whole_amount,omitempty"`
+	MaxUidLength        int32  `protobuf:"varint,8,opt,name=max_uid_length,json=maxUidLength,proto3" json:"max_uid_length,omitempty"`
+	RequiresPhone       bool   `protobuf:"varint,9,opt,name=requires_phone,json=requiresPhone,proto3" json:"requires_phone,omitempty"`
+	RequiresCustomerId  bool   `protobuf:"varint,10,opt,name=requires_customer_id,json=requiresCustomerId,proto3" json:"requires_customer_id,omitempty"`
+	RequiresCallbackUrl bool   `protobuf:"varint,11,opt,name=requires_callback_url,json=requiresCallbackUrl,proto3" json:"requires_callback_url,omitempty"`
+	Available           bool   `protobuf:"varint,12,opt,name=available,proto3" json:"available,omitempty"`
+	// CheckInvoice needs the expected amount.
+	CheckRequiresAmount bool `protobuf:"varint,13,opt,name=check_requires_amount,json=checkRequiresAmount,proto3" json:"check_requires_amount,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ProviderInfo) Reset() {
+	*x = ProviderInfo{}
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderInfo) ProtoMessage() {}
+
+func (x *ProviderInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderInfo.ProtoReflect.Descriptor instead.
+func (*ProviderInfo) Descriptor() ([]byte, []int) {
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProviderInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProviderInfo) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ProviderInfo) GetLogoUrl() string {
+	if x != nil {
+		return x.LogoUrl
+	}
+	return ""
+}
+
+func (x *ProviderInfo) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *ProviderInfo) GetMinAmount() *Money {
+	if x != nil {
+		return x.MinAmount
+	}
+	return nil
+}
+
+func (x *ProviderInfo) GetMaxAmount() *Money {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return nil
+}
+
+func (x *ProviderInfo) GetWholeAmount() bool {
+	if x != nil {
+		return x.WholeAmount
+	}
+	return false
+}
+
+func (x *ProviderInfo) GetMaxUidLength() int32 {
+	if x != nil {
+		return x.MaxUidLength
+	}
+	return 0
+}
+
+func (x *ProviderInfo) GetRequiresPhone() bool {
+	if x != nil {
+		return x.RequiresPhone
+	}
+	return false
+}
+
+func (x *ProviderInfo) GetRequiresCustomerId() bool {
+	if x != nil {
+		return x.RequiresCustomerId
+	}
+	return false
+}
+
+func (x *ProviderInfo) GetRequiresCallbackUrl() bool {
+	if x != nil {
+		return x.RequiresCallbackUrl
+	}
+	return false
+}
+
+func (x *ProviderInfo) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *ProviderInfo) GetCheckRequiresAmount() bool {
+	if x != nil {
+		return x.CheckRequiresAmount
+	}
+	return false
+}
+
+var File_sdk_grpcapi_gatewayv1_gateway_proto protoreflect.FileDescriptor
+
+const file_sdk_grpcapi_gatewayv1_gateway_proto_rawDesc = "" +
+	"\n" +
+	"#sdk/grpcapi/gatewayv1/gateway.proto\x12\x13payments.gateway.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"9\n" +
+	"\x05Money\x12\x14\n" +
+	"\x05minor\x18\x01 \x01(\x03R\x05minor\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"h\n" +
+	"\bDeeplink\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04link\x18\x03 \x01(\tR\x04link\x12\x12\n" +
+	"\x04logo\x18\x04 \x01(\tR\x04logo\"\xa6\x02\n" +
+	"\x14CreateInvoiceRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x122\n" +
+	"\x06amount\x18\x03 \x01(\v2\x1a.payments.gateway.v1.MoneyR\x06amount\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x1f\n" +
+	"\vcustomer_id\x18\x05 \x01(\x04R\n" +
+	"customerId\x12\x12\n" +
+	"\x04note\x18\x06 \x01(\tR\x04note\x12!\n" +
+	"\fcallback_url\x18\a \x01(\tR\vcallbackUrl\x12\x1f\n" +
+	"\vreturn_type\x18\b \x01(\tR\n" +
+	"returnType\x12%\n" +
+	"\x0eexpire_minutes\x18\t \x01(\x05R\rexpireMinutes\"\x9c\x02\n" +
+	"\x15CreateInvoiceResponse\x12&\n" +
+	"\x0fbank_invoice_id\x18\x01 \x01(\tR\rbankInvoiceId\x12 \n" +
+	"\fbank_qr_code\x18\x02 \x01(\tR\n" +
+	"bankQrCode\x12;\n" +
+	"\tdeeplinks\x18\x03 \x03(\v2\x1d.payments.gateway.v1.DeeplinkR\tdeeplinks\x12\x17\n" +
+	"\ais_paid\x18\x04 \x01(\bR\x06isPaid\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12(\n" +
+	"\x03raw\x18\x06 \x01(\v2\x16.google.protobuf.ValueR\x03raw\"o\n" +
+	"\x13CheckInvoiceRequest\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\tR\x03uid\x122\n" +
+	"\x06amount\x18\x03 \x01(\v2\x1a.payments.gateway.v1.MoneyR\x06amount\"\xc2\x02\n" +
+	"\x14CheckInvoiceResponse\x12\x17\n" +
+	"\ais_paid\x18\x01 \x01(\bR\x06isPaid\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\".payments.gateway.v1.PaymentStatusR\x06status\x12;\n" +
+	"\vpaid_amount\x18\x03 \x01(\v2\x1a.payments.gateway.v1.MoneyR\n" +
+	"paidAmount\x123\n" +
+	"\apaid_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\x12'\n" +
+	"\x0ftransaction_ids\x18\x05 \x03(\tR\x0etransactionIds\x12\x10\n" +
+	"\x03msg\x18\x06 \x01(\tR\x03msg\x12(\n" +
+	"\x03raw\x18\a \x01(\v2\x16.google.protobuf.ValueR\x03raw\"\x90\x01\n" +
+	"\x13WatchInvoiceRequest\x12>\n" +
+	"\x05check\x18\x01 \x01(\v2(.payments.gateway.v1.CheckInvoiceRequestR\x05check\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x9f\x02\n" +
+	"\x14WatchInvoiceResponse\x12K\n" +
+	"\x0fprevious_status\x18\x01 \x01(\x0e2\".payments.gateway.v1.PaymentStatusR\x0epreviousStatus\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\".payments.gateway.v1.PaymentStatusR\x06status\x12A\n" +
+	"\x06result\x18\x03 \x01(\v2).payments.gateway.v1.CheckInvoiceResponseR\x06result\x12;\n" +
+	"\vobserved_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"observedAt\"\x16\n" +
+	"\x14ListProvidersRequest\"X\n" +
+	"\x15ListProvidersResponse\x12?\n" +
+	"\tproviders\x18\x01 \x03(\v2!.payments.gateway.v1.ProviderInfoR\tproviders\"\x9e\x04\n" +
+	"\fProviderInfo\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x19\n" +
+	"\blogo_url\x18\x03 \x01(\tR\alogoUrl\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x04 \x03(\tR\n" +
+	"operations\x129\n" +
+	"\n" +
+	"min_amount\x18\x05 \x01(\v2\x1a.payments.gateway.v1.MoneyR\tminAmount\x129\n" +
+	"\n" +
+	"max_amount\x18\x06 \x01(\v2\x1a.payments.gateway.v1.MoneyR\tmaxAmount\x12!\n" +
+	"\fwhole_amount\x18\a \x01(\bR\vwholeAmount\x12$\n" +
+	"\x0emax_uid_length\x18\b \x01(\x05R\fmaxUidLength\x12%\n" +
+	"\x0erequires_phone\x18\t \x01(\bR\rrequiresPhone\x120\n" +
+	"\x14requires_customer_id\x18\n" +
+	" \x01(\bR\x12requiresCustomerId\x122\n" +
+	"\x15requires_callback_url\x18\v \x01(\bR\x13requiresCallbackUrl\x12\x1c\n" +
+	"\tavailable\x18\f \x01(\bR\tavailable\x122\n" +
+	"\x15check_requires_amount\x18\r \x01(\bR\x13checkRequiresAmount*\xf9\x01\n" +
+	"\rPaymentStatus\x12\x1e\n" +
+	"\x1aPAYMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16PAYMENT_STATUS_PENDING\x10\x01\x12\x17\n" +
+	"\x13PAYMENT_STATUS_PAID\x10\x02\x12!\n" +
+	"\x1dPAYMENT_STATUS_PARTIALLY_PAID\x10\x03\x12\x19\n" +
+	"\x15PAYMENT_STATUS_FAILED\x10\x04\x12\x1a\n" +
+	"\x16PAYMENT_STATUS_EXPIRED\x10\x05\x12\x1c\n" +
+	"\x18PAYMENT_STATUS_CANCELLED\x10\x06\x12\x1b\n" +
+	"\x17PAYMENT_STATUS_REFUNDED\x10\a2\xac\x03\n" +
+	"\x0eGatewayService\x12f\n" +
+	"\rCreateInvoice\x12).payments.gateway.v1.CreateInvoiceRequest\x1a*.payments.gateway.v1.CreateInvoiceResponse\x12c\n" +
+	"\fCheckInvoice\x12(.payments.gateway.v1.CheckInvoiceRequest\x1a).payments.gateway.v1.CheckInvoiceResponse\x12e\n" +
+	"\fWatchInvoice\x12(.payments.gateway.v1.WatchInvoiceRequest\x1a).payments.gateway.v1.WatchInvoiceResponse0\x01\x12f\n" +
+	"\rListProviders\x12).payments.gateway.v1.ListProvidersRequest\x1a*.payments.gateway.v1.ListProvidersResponseBOZMgithub.com/techpartners-asia/payments-gateway/sdk/grpcapi/gatewayv1;gatewayv1b\x06proto3"
+
+var (
+	file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescOnce sync.Once
+	file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescData []byte
+)
+
+func file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescGZIP() []byte {
+	file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescOnce.Do(func() {
+		file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sdk_grpcapi_gatewayv1_gateway_proto_rawDesc), len(file_sdk_grpcapi_gatewayv1_gateway_proto_rawDesc)))
+	})
+	return file_sdk_grpcapi_gatewayv1_gateway_proto_rawDescData
+}
+
+var file_sdk_grpcapi_gatewayv1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_sdk_grpcapi_gatewayv1_gateway_proto_goTypes = []any{
+	(PaymentStatus)(0),            // 0: payments.gateway.v1.PaymentStatus
+	(*Money)(nil),                 // 1: payments.gateway.v1.Money
+	(*Deeplink)(nil),              // 2: payments.gateway.v1.Deeplink
+	(*CreateInvoiceRequest)(nil),  // 3: payments.gateway.v1.CreateInvoiceRequest
+	(*CreateInvoiceResponse)(nil), // 4: payments.gateway.v1.CreateInvoiceResponse
+	(*CheckInvoiceRequest)(nil),   // 5: payments.gateway.v1.CheckInvoiceRequest
+	(*CheckInvoiceResponse)(nil),  // 6: payments.gateway.v1.CheckInvoiceResponse
+	(*WatchInvoiceRequest)(nil),   // 7: payments.gateway.v1.WatchInvoiceRequest
+	(*WatchInvoiceResponse)(nil),  // 8: payments.gateway.v1.WatchInvoiceResponse
+	(*ListProvidersRequest)(nil),  // 9: payments.gateway.v1.ListProvidersRequest
+	(*ListProvidersResponse)(nil), // 10: payments.gateway.v1.ListProvidersResponse
+	(*ProviderInfo)(nil),          // 11: payments.gateway.v1.ProviderInfo
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*structpb.Value)(nil),        // 13: google.protobuf.Value
+}
+var file_sdk_grpcapi_gatewayv1_gateway_proto_depIdxs = []int32{
+	1,  // 0: payments.gateway.v1.CreateInvoiceRequest.amount:type_name -> payments.gateway.v1.Money
+	2,  // 1: payments.gateway.v1.CreateInvoiceResponse.deeplinks:type_name -> payments.gateway.v1.Deeplink
+	12, // 2: payments.gateway.v1.CreateInvoiceResponse.expires_at:type_name -> google.protobuf.Timestamp
+	13, // 3: payments.gateway.v1.CreateInvoiceResponse.raw:type_name -> google.protobuf.Value
+	1,  // 4: payments.gateway.v1.CheckInvoiceRequest.amount:type_name -> payments.gateway.v1.Money
+	0,  // 5: payments.gateway.v1.CheckInvoiceResponse.status:type_name -> payments.gateway.v1.PaymentStatus
+	1,  // 6: payments.gateway.v1.CheckInvoiceResponse.paid_amount:type_name -> payments.gateway.v1.Money
+	12, // 7: payments.gateway.v1.CheckInvoiceResponse.paid_at:type_name -> google.protobuf.Timestamp
+	13, // 8: payments.gateway.v1.CheckInvoiceResponse.raw:type_name -> google.protobuf.Value
+	5,  // 9: payments.gateway.v1.WatchInvoiceRequest.check:type_name -> payments.gateway.v1.CheckInvoiceRequest
+	12, // 10: payments.gateway.v1.WatchInvoiceRequest.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 11: payments.gateway.v1.WatchInvoiceResponse.previous_status:type_name -> payments.gateway.v1.PaymentStatus
+	0,  // 12: payments.gateway.v1.WatchInvoiceResponse.status:type_name -> payments.gateway.v1.PaymentStatus
+	6,  // 13: payments.gateway.v1.WatchInvoiceResponse.result:type_name -> payments.gateway.v1.CheckInvoiceResponse
+	12, // 14: payments.gateway.v1.WatchInvoiceResponse.observed_at:type_name -> google.protobuf.Timestamp
+	11, // 15: payments.gateway.v1.ListProvidersResponse.providers:type_name -> payments.gateway.v1.ProviderInfo
+	1,  // 16: payments.gateway.v1.ProviderInfo.min_amount:type_name -> payments.gateway.v1.Money
+	1,  // 17: payments.gateway.v1.ProviderInfo.max_amount:type_name -> payments.gateway.v1.Money
+	3,  // 18: payments.gateway.v1.GatewayService.CreateInvoice:input_type -> payments.gateway.v1.CreateInvoiceRequest
+	5,  // 19: payments.gateway.v1.GatewayService.CheckInvoice:input_type -> payments.gateway.v1.CheckInvoiceRequest
+	7,  // 20: payments.gateway.v1.GatewayService.WatchInvoice:input_type -> payments.gateway.v1.WatchInvoiceRequest
+	9,  // 21: payments.gateway.v1.GatewayService.ListProviders:input_type -> payments.gateway.v1.ListProvidersRequest
+	4,  // 22: payments.gateway.v1.GatewayService.CreateInvoice:output_type -> payments.gateway.v1.CreateInvoiceResponse
+	6,  // 23: payments.gateway.v1.GatewayService.CheckInvoice:output_type -> payments.gateway.v1.CheckInvoiceResponse
+	8,  // 24: payments.gateway.v1.GatewayService.WatchInvoice:output_type -> payments.gateway.v1.WatchInvoiceResponse
+	10, // 25: payments.gateway.v1.GatewayService.ListProviders:output_type -> payments.gateway.v1.ListProvidersResponse
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_sdk_grpcapi_gatewayv1_gateway_proto_init() }
+func file_sdk_grpcapi_gatewayv1_gateway_proto_init() {
+	if File_sdk_grpcapi_gatewayv1_gateway_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sdk_grpcapi_gatewayv1_gateway_proto_rawDesc), len(file_sdk_grpcapi_gatewayv1_gateway_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sdk_grpcapi_gatewayv1_gateway_proto_goTypes,
+		DependencyIndexes: file_sdk_grpcapi_gatewayv1_gateway_proto_depIdxs,
+		EnumInfos:         file_sdk_grpcapi_gatewayv1_gateway_proto_enumTypes,
+		MessageInfos:      file_sdk_grpcapi_gatewayv1_gateway_proto_msgTypes,
+	}.Build()
+	File_sdk_grpcapi_gatewayv1_gateway_proto = out.File
+	file_sdk_grpcapi_gatewayv1_gateway_proto_goTypes = nil
+	file_sdk_grpcapi_gatewayv1_gateway_proto_depIdxs = nil
+}
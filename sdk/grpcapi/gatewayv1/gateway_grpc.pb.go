@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: sdk/grpcapi/gatewayv1/gateway.proto
+
+// Package payments.gateway.v1 exposes the payments SDK over gRPC.
+
+package gatewayv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GatewayService_CreateInvoice_FullMethodName = "/payments.gateway.v1.GatewayService/CreateInvoice"
+	GatewayService_CheckInvoice_FullMethodName  = "/payments.gateway.v1.GatewayService/CheckInvoice"
+	GatewayService_WatchInvoice_FullMethodName  = "/payments.gateway.v1.GatewayService/WatchInvoice"
+	GatewayService_ListProviders_FullMethodName = "/payments.gateway.v1.GatewayService/ListProviders"
+)
+
+// GatewayServiceClient is the client API for GatewayService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GatewayService creates and checks invoices at the configured providers.
+// Failed calls carry a google.rpc.ErrorInfo whose reason is the SDK error
+// code (invalid_input, provider_unavailable, ...).
+type GatewayServiceClient interface {
+	// CreateInvoice validates the request against the provider requirements and
+	// creates the invoice. Repeating a request with the same type and uid
+	// returns the original invoice.
+	CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error)
+	// CheckInvoice asks the provider for the current status of an invoice.
+	CheckInvoice(ctx context.Context, in *CheckInvoiceRequest, opts ...grpc.CallOption) (*CheckInvoiceResponse, error)
+	// WatchInvoice sends the current status of an invoice, then every status
+	// change until the invoice is final. The stream ends after the final
+	// status, or with an EXPIRED update once a check after expires_at still
+	// finds the invoice open. It fails like CheckInvoice when the first check
+	// does, and later checks are retried while the provider is unavailable.
+	WatchInvoice(ctx context.Context, in *WatchInvoiceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInvoiceResponse], error)
+	// ListProviders describes the configured providers for checkout pickers.
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+}
+
+type gatewayServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGatewayServiceClient(cc grpc.ClientConnInterface) GatewayServiceClient {
+	return &gatewayServiceClient{cc}
+}
+
+func (c *gatewayServiceClient) CreateInvoice(ctx context.Context, in *CreateInvoiceRequest, opts ...grpc.CallOption) (*CreateInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInvoiceResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CreateInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) CheckInvoice(ctx context.Context, in *CheckInvoiceRequest, opts ...grpc.CallOption) (*CheckInvoiceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckInvoiceResponse)
+	err := c.cc.Invoke(ctx, GatewayService_CheckInvoice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gatewayServiceClient) WatchInvoice(ctx context.Context, in *WatchInvoiceRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchInvoiceResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GatewayService_ServiceDesc.Streams[0], GatewayService_WatchInvoice_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchInvoiceRequest, WatchInvoiceResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GatewayService_WatchInvoiceClient = grpc.ServerStreamingClient[WatchInvoiceResponse]
+
+func (c *gatewayServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, GatewayService_ListProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GatewayServiceServer is the server API for GatewayService service.
+// All implementations must embed UnimplementedGatewayServiceServer
+// for forward compatibility.
+//
+// GatewayService creates and checks invoices at the configured providers.
+// Failed calls carry a google.rpc.ErrorInfo whose reason is the SDK error
+// code (invalid_input, provider_unavailable, ...).
+type GatewayServiceServer interface {
+	// CreateInvoice validates the request against the provider requirements and
+	// creates the invoice. Repeating a request with the same type and uid
+	// returns the original invoice.
+	CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error)
+	// CheckInvoice asks the provider for the current status of an invoice.
+	CheckInvoice(context.Context, *CheckInvoiceRequest) (*CheckInvoiceResponse, error)
+	// WatchInvoice sends the current status of an invoice, then every status
+	// change until the invoice is final. The stream ends after the final
+	// status, or with an EXPIRED update once a check after expires_at still
+	// finds the invoice open. It fails like CheckInvoice when the first check
+	// does, and later checks are retried while the provider is unavailable.
+	WatchInvoice(*WatchInvoiceRequest, grpc.ServerStreamingServer[WatchInvoiceResponse]) error
+	// ListProviders describes the configured providers for checkout pickers.
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	mustEmbedUnimplementedGatewayServiceServer()
+}
+
+// UnimplementedGatewayServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGatewayServiceServer struct{}
+
+func (UnimplementedGatewayServiceServer) CreateInvoice(context.Context, *CreateInvoiceRequest) (*CreateInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvoice not implemented")
+}
+func (UnimplementedGatewayServiceServer) CheckInvoice(context.Context, *CheckInvoiceRequest) (*CheckInvoiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckInvoice not implemented")
+}
+func (UnimplementedGatewayServiceServer) WatchInvoice(*WatchInvoiceRequest, grpc.ServerStreamingServer[WatchInvoiceResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchInvoice not implemented")
+}
+func (UnimplementedGatewayServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedGatewayServiceServer) mustEmbedUnimplementedGatewayServiceServer() {}
+func (UnimplementedGatewayServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeGatewayServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GatewayServiceServer will
+// result in compilation errors.
+type UnsafeGatewayServiceServer interface {
+	mustEmbedUnimplementedGatewayServiceServer()
+}
+
+func RegisterGatewayServiceServer(s grpc.ServiceRegistrar, srv GatewayServiceServer) {
+	// If the following call pancis, it indicates UnimplementedGatewayServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GatewayService_ServiceDesc, srv)
+}
+
+func _GatewayService_CreateInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CreateInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CreateInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CreateInvoice(ctx, req.(*CreateInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_CheckInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).CheckInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_CheckInvoice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).CheckInvoice(ctx, req.(*CheckInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GatewayService_WatchInvoice_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchInvoiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GatewayServiceServer).WatchInvoice(m, &grpc.GenericServerStream[WatchInvoiceRequest, WatchInvoiceResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GatewayService_WatchInvoiceServer = grpc.ServerStreamingServer[WatchInvoiceResponse]
+
+func _GatewayService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GatewayServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GatewayService_ListProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GatewayServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GatewayService_ServiceDesc is the grpc.ServiceDesc for GatewayService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GatewayService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payments.gateway.v1.GatewayService",
+	HandlerType: (*GatewayServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateInvoice",
+			Handler:    _GatewayService_CreateInvoice_Handler,
+		},
+		{
+			MethodName: "CheckInvoice",
+			Handler:    _GatewayService_CheckInvoice_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _GatewayService_ListProviders_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchInvoice",
+			Handler:       _GatewayService_WatchInvoice_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "sdk/grpcapi/gatewayv1/gateway.proto",
+}
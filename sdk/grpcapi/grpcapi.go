@@ -0,0 +1,287 @@
+// Package grpcapi serves an SDK as the gatewayv1.GatewayService gRPC service:
+// create and check invoices, watch an invoice until it settles and list the
+// configured providers.
+package grpcapi
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative sdk/grpcapi/gatewayv1/gateway.proto
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/techpartners-asia/payments-gateway/internal/apierr"
+	"github.com/techpartners-asia/payments-gateway/internal/apikey"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/grpcapi/gatewayv1"
+	"github.com/techpartners-asia/payments-gateway/sdk/poller"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+const (
+	defaultWatchInterval = 5 * time.Second
+	defaultMaxWatch      = 30 * time.Minute
+	defaultCheckTimeout  = 30 * time.Second
+)
+
+type Input struct {
+	SDK sdk.SDK
+	// Config is optional; its provider credentials are masked in error
+	// messages sent to clients.
+	Config sdk.Input
+	// APIKeys are accepted in the "authorization: Bearer <key>" or
+	// "x-api-key" metadata. At least one is required.
+	APIKeys       []string
+	WatchInterval time.Duration // delay between checks of a watched invoice, default 5s
+	// MaxWatch bounds a watch without expires_at, default 30m. The stream
+	// then ends with DeadlineExceeded. It also bounds how long checks past
+	// expires_at are retried while the provider is unavailable.
+	MaxWatch     time.Duration
+	CheckTimeout time.Duration // per check of a watched invoice, default 30s
+	Logger       *slog.Logger  // defaults to discarding logs
+}
+
+// Server implements gatewayv1.GatewayServiceServer over an SDK.
+type Server struct {
+	gatewayv1.UnimplementedGatewayServiceServer
+
+	input    Input
+	redactor *types.Redactor
+}
+
+var _ gatewayv1.GatewayServiceServer = (*Server)(nil)
+
+func New(input Input) (*Server, error) {
+	if input.SDK == nil {
+		return nil, errors.New("grpcapi: SDK is required")
+	}
+	var keys []string
+	for _, key := range input.APIKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("grpcapi: at least one API key is required")
+	}
+	input.APIKeys = keys
+	if input.WatchInterval <= 0 {
+		input.WatchInterval = defaultWatchInterval
+	}
+	if input.MaxWatch <= 0 {
+		input.MaxWatch = defaultMaxWatch
+	}
+	if input.CheckTimeout <= 0 {
+		input.CheckTimeout = defaultCheckTimeout
+	}
+	if input.Logger == nil {
+		input.Logger = slog.New(slog.DiscardHandler)
+	}
+	return &Server{
+		input:    input,
+		redactor: types.NewRedactor(types.SecretValues(input.Config)...),
+	}, nil
+}
+
+// Register adds the service and its API key check to a new gRPC server:
+//
+//	server := grpc.NewServer(api.ServerOptions()...)
+//	api.Register(server)
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	gatewayv1.RegisterGatewayServiceServer(registrar, s)
+}
+
+// ServerOptions returns the interceptors enforcing the API keys.
+func (s *Server) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authorize(ctx); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(stream.Context()); err != nil {
+				return err
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+func (s *Server) CreateInvoice(ctx context.Context, req *gatewayv1.CreateInvoiceRequest) (*gatewayv1.CreateInvoiceResponse, error) {
+	if err := validateCreate(req); err != nil {
+		return nil, s.status(ctx, err)
+	}
+	result, err := s.input.SDK.CreateContext(ctx, invoiceInput(req))
+	if result == nil {
+		return nil, s.status(ctx, apierr.NoResult(err))
+	}
+	if err != nil {
+		// The invoice exists at the provider; failing here would make the
+		// client create it again.
+		s.input.Logger.ErrorContext(ctx, "invoice created with error", "provider", req.GetType(), "uid", req.GetUid(), "error", s.redactor.String(err.Error()))
+	}
+	return createResponse(result), nil
+}
+
+func (s *Server) CheckInvoice(ctx context.Context, req *gatewayv1.CheckInvoiceRequest) (*gatewayv1.CheckInvoiceResponse, error) {
+	result, err := s.check(ctx, req)
+	if err != nil {
+		return nil, s.status(ctx, err)
+	}
+	return checkResponse(result), nil
+}
+
+func (s *Server) WatchInvoice(req *gatewayv1.WatchInvoiceRequest, stream grpc.ServerStreamingServer[gatewayv1.WatchInvoiceResponse]) error {
+	ctx := stream.Context()
+	if req.GetCheck().GetType() == "" || req.GetCheck().GetUid() == "" {
+		return s.status(ctx, apierr.InvalidRequest("check", "type and uid are required"))
+	}
+
+	expiresAt := time.Now().Add(s.input.MaxWatch)
+	expires := req.GetExpiresAt() != nil
+	if expires {
+		expiresAt = req.GetExpiresAt().AsTime()
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, s.input.CheckTimeout)
+	result, err := s.check(checkCtx, req.GetCheck())
+	cancel()
+	if err != nil {
+		return s.status(ctx, err)
+	}
+	if err := s.sendWatch(stream, "", result.Status, result, time.Now()); err != nil || result.Status.IsFinal() {
+		return err
+	}
+
+	// Later checks run on a poller of their own, which settles the invoice
+	// only on a successful check and reports EXPIRED only when a check past
+	// expiresAt still finds it open.
+	paymentType := types.PaymentType(req.GetCheck().GetType())
+	p, err := poller.New(poller.Input{
+		SDK:          s.input.SDK,
+		Concurrency:  1,
+		CheckTimeout: s.input.CheckTimeout,
+		MaxAge:       s.input.MaxWatch,
+		Cadence:      map[types.PaymentType]poller.Cadence{paymentType: {Initial: s.input.WatchInterval, Max: s.input.WatchInterval, Multiplier: 1}},
+	})
+	if err != nil {
+		return s.status(ctx, err)
+	}
+	events := make(chan poller.Event)
+	p.Subscribe(func(ctx context.Context, event poller.Event) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+	p.Watch(poller.Job{
+		Check: types.CheckInvoiceInput{
+			Type:   paymentType,
+			UID:    req.GetCheck().GetUid(),
+			Amount: money(req.GetCheck().GetAmount()),
+		},
+		Status:    result.Status,
+		ExpiresAt: expiresAt,
+	})
+
+	pollCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = p.Run(pollCtx)
+	}()
+	defer func() {
+		stop()
+		<-done
+	}()
+
+	for {
+		var event poller.Event
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event = <-events:
+		}
+
+		switch {
+		case event.Err != nil && (event.Stopped || !sdk.IsRetryable(event.Err)):
+			return s.status(ctx, event.Err)
+		case event.Err != nil:
+			s.input.Logger.WarnContext(ctx, "watch check failed", "provider", event.Type, "uid", event.UID, "error", s.redactor.String(event.Err.Error()))
+			continue
+		case event.Status == types.PaymentStatusExpired && !expires:
+			return status.Error(codes.DeadlineExceeded, "invoice did not settle within the watch limit")
+		}
+		if err := s.sendWatch(stream, event.From, event.Status, event.Result, event.At); err != nil || event.Status.IsFinal() {
+			return err
+		}
+	}
+}
+
+func (s *Server) sendWatch(stream grpc.ServerStreamingServer[gatewayv1.WatchInvoiceResponse], from, to types.PaymentStatus, result *types.CheckInvoiceResult, at time.Time) error {
+	res := &gatewayv1.WatchInvoiceResponse{
+		PreviousStatus: paymentStatus(from),
+		Status:         paymentStatus(to),
+		ObservedAt:     timestamppb.New(at),
+	}
+	if result != nil {
+		res.Result = checkResponse(result)
+	}
+	return stream.Send(res)
+}
+
+func (s *Server) ListProviders(ctx context.Context, req *gatewayv1.ListProvidersRequest) (*gatewayv1.ListProvidersResponse, error) {
+	infos := s.input.SDK.AvailableProviders()
+	res := &gatewayv1.ListProvidersResponse{Providers: make([]*gatewayv1.ProviderInfo, len(infos))}
+	for i, info := range infos {
+		res.Providers[i] = providerInfo(info)
+	}
+	return res, nil
+}
+
+func (s *Server) check(ctx context.Context, req *gatewayv1.CheckInvoiceRequest) (*types.CheckInvoiceResult, error) {
+	if req.GetType() == "" || req.GetUid() == "" {
+		var v types.Validator
+		v.Required("type", req.GetType())
+		v.Required("uid", req.GetUid())
+		return nil, v.Err()
+	}
+	result, err := s.input.SDK.CheckContext(ctx, types.CheckInvoiceInput{
+		Type:   types.PaymentType(req.GetType()),
+		UID:    req.GetUid(),
+		Amount: money(req.GetAmount()),
+	})
+	if result == nil {
+		return nil, apierr.NoResult(err)
+	}
+	if err != nil {
+		s.input.Logger.ErrorContext(ctx, "invoice checked with error", "provider", req.GetType(), "uid", req.GetUid(), "error", s.redactor.String(err.Error()))
+	}
+	return result, nil
+}
+
+func (s *Server) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !apikey.Valid(apikey.FromHeaders(first(md, "x-api-key"), first(md, "authorization")), s.input.APIKeys) {
+		return status.Error(codes.Unauthenticated, "missing or invalid API key")
+	}
+	return nil
+}
+
+// first returns the first value of key in md, or "" when it is not set.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,155 @@
+package grpcapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/grpcapi/gatewayv1"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+var errUnavailable = &types.ProviderError{Type: "fake", Operation: types.OperationCheck, Kind: types.ErrProviderUnavailable, Message: "timeout"}
+
+// watchStream records the responses of a WatchInvoice call.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*gatewayv1.WatchInvoiceResponse
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(res *gatewayv1.WatchInvoiceResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+func TestWatchInvoice(t *testing.T) {
+	var (
+		pending = fakeprovider.Answer{Status: types.PaymentStatusPending}
+		paid    = fakeprovider.Answer{Status: types.PaymentStatusPaid}
+		down    = fakeprovider.Answer{Err: errUnavailable}
+	)
+	tests := []struct {
+		name      string
+		answers   []fakeprovider.Answer
+		noAmount  bool
+		expiresIn time.Duration // unset watches for MaxWatch
+		want      []gatewayv1.PaymentStatus
+		wantCode  codes.Code
+	}{
+		{
+			name:    "settles",
+			answers: []fakeprovider.Answer{pending, pending, paid},
+			want:    []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING, gatewayv1.PaymentStatus_PAYMENT_STATUS_PAID},
+		},
+		{
+			name:    "already final",
+			answers: []fakeprovider.Answer{paid},
+			want:    []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PAID},
+		},
+		{
+			name:    "outage during watch",
+			answers: []fakeprovider.Answer{pending, down, down, paid},
+			want:    []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING, gatewayv1.PaymentStatus_PAYMENT_STATUS_PAID},
+		},
+		{
+			name:      "expires open",
+			answers:   []fakeprovider.Answer{pending},
+			expiresIn: 30 * time.Millisecond,
+			want:      []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING, gatewayv1.PaymentStatus_PAYMENT_STATUS_EXPIRED},
+		},
+		{
+			name:      "outage past expiry",
+			answers:   []fakeprovider.Answer{pending, down},
+			expiresIn: 30 * time.Millisecond,
+			want:      []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING},
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "paid at expiry",
+			answers:   []fakeprovider.Answer{pending, down, down, paid},
+			expiresIn: 10 * time.Millisecond,
+			want:      []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING, gatewayv1.PaymentStatus_PAYMENT_STATUS_PAID},
+		},
+		{
+			name:     "watch limit",
+			answers:  []fakeprovider.Answer{pending},
+			want:     []gatewayv1.PaymentStatus{gatewayv1.PaymentStatus_PAYMENT_STATUS_PENDING},
+			wantCode: codes.DeadlineExceeded,
+		},
+		{
+			name:     "first check fails",
+			answers:  []fakeprovider.Answer{down},
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "missing amount",
+			answers:  []fakeprovider.Answer{paid},
+			noAmount: true,
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := New(Input{
+				SDK: sdk.NewSDK(sdk.Input{
+					Providers: map[types.PaymentType]types.PaymentProvider{"fake": &fakeprovider.Provider{
+						ProviderInfo: types.ProviderInfo{DisplayName: "Fake", CheckRequiresAmount: true},
+						Answers:      tt.answers,
+					}},
+					Retry:   map[types.Operation]sdk.RetryPolicy{types.OperationCheck: {MaxAttempts: 1}},
+					Breaker: sdk.BreakerPolicy{Disabled: true},
+				}),
+				APIKeys:       []string{"key"},
+				WatchInterval: 5 * time.Millisecond,
+				MaxWatch:      100 * time.Millisecond,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req := &gatewayv1.WatchInvoiceRequest{Check: &gatewayv1.CheckInvoiceRequest{Type: "fake", Uid: "order-1", Amount: &gatewayv1.Money{Minor: 10000}}}
+			if tt.noAmount {
+				req.Check.Amount = nil
+			}
+			if tt.expiresIn != 0 {
+				req.ExpiresAt = timestamppb.New(time.Now().Add(tt.expiresIn))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			stream := &watchStream{ctx: ctx}
+
+			err = server.WatchInvoice(req, stream)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %v (%v), want %v", code, err, tt.wantCode)
+			}
+			var got []gatewayv1.PaymentStatus
+			for i, res := range stream.sent {
+				got = append(got, res.GetStatus())
+				if res.GetResult() == nil {
+					t.Errorf("update %d (%v) has no check result", i, res.GetStatus())
+				}
+				if i > 0 && res.GetPreviousStatus() != stream.sent[i-1].GetStatus() {
+					t.Errorf("update %d previous = %v, want %v", i, res.GetPreviousStatus(), stream.sent[i-1].GetStatus())
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("statuses = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("statuses = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/webhook"
+)
+
+// ForwardEvents returns an event handler that POSTs every confirmed callback
+// as JSON to url. A non-2xx answer fails the callback, so the provider
+// delivers it again. A nil client uses http.DefaultClient.
+func ForwardEvents(url string, client *http.Client) webhook.EventHandler {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context, event webhook.PaymentEvent) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("forward event: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("forward event: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("forward event: %w", err)
+		}
+		defer res.Body.Close()
+		_, _ = io.Copy(io.Discard, res.Body)
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("forward event: %s answered %s", url, res.Status)
+		}
+		return nil
+	}
+}
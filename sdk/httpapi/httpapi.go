@@ -0,0 +1,341 @@
+// Package httpapi exposes an SDK as a REST/JSON API for services that cannot
+// import it: create and check invoices, list providers and receive provider
+// callbacks. The API is described by the embedded OpenAPI document.
+package httpapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/techpartners-asia/payments-gateway/internal/apierr"
+	"github.com/techpartners-asia/payments-gateway/internal/apikey"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+	"github.com/techpartners-asia/payments-gateway/sdk/webhook"
+)
+
+const defaultMaxBodyBytes = 1 << 20
+
+// ErrorCodeUnauthorized is returned for requests without a valid API key.
+const ErrorCodeUnauthorized types.ErrorCode = "unauthorized"
+
+// OpenAPI is the OpenAPI 3 document of the API, served at /openapi.yaml.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
+
+type Input struct {
+	SDK sdk.SDK
+	// Config holds the provider secrets used to verify signed callbacks and
+	// to mask credentials in error details.
+	Config sdk.Input
+	// APIKeys are accepted as "Authorization: Bearer <key>" or "X-API-Key".
+	// At least one is required; callbacks, /healthz and /openapi.yaml are
+	// not authenticated.
+	APIKeys []string
+	// Events receives every confirmed provider callback, e.g. ForwardEvents.
+	// Nil acknowledges callbacks after logging them.
+	Events webhook.EventHandler
+	// Lookup maps callback UIDs to the invoices to check, e.g.
+	// webhook.StoreLookup. Callbacks are only served when it is set.
+	Lookup       webhook.Lookup
+	Logger       *slog.Logger // defaults to discarding logs
+	MaxBodyBytes int64        // request body limit, defaults to 1 MiB
+}
+
+// Server is the http.Handler serving the API.
+type Server struct {
+	input    Input
+	mux      *http.ServeMux
+	redactor *types.Redactor
+}
+
+func New(input Input) (*Server, error) {
+	if input.SDK == nil {
+		return nil, errors.New("httpapi: SDK is required")
+	}
+	var keys []string
+	for _, key := range input.APIKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("httpapi: at least one API key is required")
+	}
+	input.APIKeys = keys
+	if input.Logger == nil {
+		input.Logger = slog.New(slog.DiscardHandler)
+	}
+	if input.MaxBodyBytes <= 0 {
+		input.MaxBodyBytes = defaultMaxBodyBytes
+	}
+
+	s := &Server{
+		input:    input,
+		mux:      http.NewServeMux(),
+		redactor: types.NewRedactor(types.SecretValues(input.Config)...),
+	}
+	if s.input.Events == nil {
+		s.input.Events = s.logEvent
+	}
+
+	s.mux.HandleFunc("GET /healthz", s.healthz)
+	s.mux.HandleFunc("GET /openapi.yaml", s.openAPI)
+	s.mux.Handle("GET /v1/providers", s.auth(http.HandlerFunc(s.listProviders)))
+	s.mux.Handle("POST /v1/invoices", s.auth(http.HandlerFunc(s.createInvoice)))
+	s.mux.Handle("GET /v1/invoices/{type}/{uid}", s.auth(http.HandlerFunc(s.checkInvoice)))
+	if input.Lookup != nil {
+		receiver, err := webhook.New(webhook.Input{
+			SDK:          input.SDK,
+			Config:       input.Config,
+			Handler:      s.input.Events,
+			Lookup:       input.Lookup,
+			MaxBodyBytes: input.MaxBodyBytes,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("httpapi: %w", err)
+		}
+		s.mux.Handle("/v1/callbacks/{type}", receiver)
+	}
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
+
+// CreateInvoiceRequest is the body of POST /v1/invoices.
+type CreateInvoiceRequest struct {
+	Type          types.PaymentType `json:"type"`
+	UID           string            `json:"uid"`
+	Amount        types.Money       `json:"amount"`
+	Phone         string            `json:"phone,omitempty"`
+	CustomerID    uint              `json:"customer_id,omitempty"`
+	Note          string            `json:"note,omitempty"`
+	CallbackURL   string            `json:"callback_url,omitempty"`
+	ReturnType    string            `json:"return_type,omitempty"`
+	ExpireMinutes int               `json:"expire_minutes,omitempty"`
+}
+
+// validate checks what every provider needs; provider rules are checked by
+// the SDK.
+func (r CreateInvoiceRequest) validate() error {
+	var v types.Validator
+	v.Required("type", string(r.Type))
+	v.Required("uid", r.UID)
+	if !r.Amount.IsPositive() {
+		v.Add("amount", "must be positive")
+	}
+	return v.Err()
+}
+
+func (r CreateInvoiceRequest) invoiceInput() types.InvoiceInput {
+	return types.InvoiceInput{
+		Amount:        r.Amount,
+		UID:           r.UID,
+		Phone:         r.Phone,
+		CustomerID:    r.CustomerID,
+		Note:          r.Note,
+		CallbackURL:   r.CallbackURL,
+		ReturnType:    r.ReturnType,
+		ExpireMinutes: r.ExpireMinutes,
+		Type:          r.Type,
+	}
+}
+
+// ErrorResponse is the body of every failed API call.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    types.ErrorCode     `json:"code"`
+	Message string              `json:"message"`          // safe to show to payers, localized by Accept-Language
+	Detail  string              `json:"detail,omitempty"` // diagnostic text with credentials masked
+	Fields  []*types.FieldError `json:"fields,omitempty"` // invalid request fields
+}
+
+func (s *Server) healthz(w http.ResponseWriter, req *http.Request) {
+	s.writeJSON(w, req, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) openAPI(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(OpenAPI)
+}
+
+func (s *Server) listProviders(w http.ResponseWriter, req *http.Request) {
+	s.writeJSON(w, req, http.StatusOK, s.input.SDK.AvailableProviders())
+}
+
+func (s *Server) createInvoice(w http.ResponseWriter, req *http.Request) {
+	var body CreateInvoiceRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, req.Body, s.input.MaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&body); err != nil {
+		s.writeError(w, req, apierr.InvalidRequest("body", err.Error()))
+		return
+	}
+	if err := body.validate(); err != nil {
+		s.writeError(w, req, err)
+		return
+	}
+
+	result, err := s.input.SDK.CreateContext(req.Context(), body.invoiceInput())
+	if result == nil {
+		s.writeError(w, req, jsonFieldNames(apierr.NoResult(err)))
+		return
+	}
+	if err != nil {
+		// The invoice exists at the provider; failing here would make the
+		// client create it again.
+		s.input.Logger.ErrorContext(req.Context(), "invoice created with error", "provider", body.Type, "uid", body.UID, "error", s.redactor.String(err.Error()))
+	}
+	s.writeJSON(w, req, http.StatusCreated, result)
+}
+
+func (s *Server) checkInvoice(w http.ResponseWriter, req *http.Request) {
+	input := types.CheckInvoiceInput{
+		Type: types.PaymentType(req.PathValue("type")),
+		UID:  req.PathValue("uid"),
+	}
+	if amount := req.URL.Query().Get("amount"); amount != "" {
+		currency := req.URL.Query().Get("currency")
+		if currency == "" {
+			currency = types.CurrencyMNT
+		}
+		money, err := types.ParseMoney(amount, currency)
+		if err != nil {
+			s.writeError(w, req, apierr.InvalidRequest("amount", err.Error()))
+			return
+		}
+		input.Amount = money
+	}
+
+	result, err := s.input.SDK.CheckContext(req.Context(), input)
+	if result == nil {
+		s.writeError(w, req, jsonFieldNames(apierr.NoResult(err)))
+		return
+	}
+	if err != nil {
+		s.input.Logger.ErrorContext(req.Context(), "invoice checked with error", "provider", input.Type, "uid", input.UID, "error", s.redactor.String(err.Error()))
+	}
+	s.writeJSON(w, req, http.StatusOK, result)
+}
+
+func (s *Server) logEvent(ctx context.Context, event webhook.PaymentEvent) error {
+	s.input.Logger.InfoContext(ctx, "payment callback", "provider", event.Type, "uid", event.UID, "status", event.Status)
+	return nil
+}
+
+// auth rejects requests without a known API key.
+func (s *Server) auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := apikey.FromHeaders(req.Header.Get("X-API-Key"), req.Header.Get("Authorization"))
+		if !apikey.Valid(key, s.input.APIKeys) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="payments"`)
+			s.writeJSON(w, req, http.StatusUnauthorized, ErrorResponse{Error: ErrorBody{Code: ErrorCodeUnauthorized, Message: "missing or invalid API key"}})
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (s *Server) writeError(w http.ResponseWriter, req *http.Request, err error) {
+	status := statusOf(err)
+	if status >= http.StatusInternalServerError {
+		s.input.Logger.ErrorContext(req.Context(), "request failed", "method", req.Method, "path", req.URL.Path, "error", s.redactor.String(err.Error()))
+	}
+
+	body := ErrorBody{
+		Code:    types.ErrorCodeOf(err),
+		Message: types.UserMessage(err, types.ParseLanguage(req.Header.Get("Accept-Language"))),
+		Detail:  s.redactor.String(err.Error()),
+	}
+	var validation *types.ValidationError
+	if errors.As(err, &validation) {
+		body.Fields = validation.Fields
+	}
+	s.writeJSON(w, req, status, ErrorResponse{Error: body})
+}
+
+// statusOf maps an SDK error to the HTTP status of the response.
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, types.ErrInvalidInput), types.ErrorCodeOf(err) == types.ErrorCodeInvalidInput:
+		return http.StatusBadRequest
+	case errors.Is(err, types.ErrNotConfigured):
+		return http.StatusNotFound
+	case errors.Is(err, types.ErrNotSupported):
+		return http.StatusNotImplemented
+	case errors.Is(err, types.ErrDuplicate), errors.Is(err, types.ErrIdempotencyConflict):
+		return http.StatusConflict
+	case errors.Is(err, types.ErrInsufficientLimit):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, types.ErrProviderUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// invoiceFields maps InvoiceInput field names in SDK validation errors to the
+// names of CreateInvoiceRequest.
+var invoiceFields = map[string]string{
+	"Type":          "type",
+	"UID":           "uid",
+	"Amount":        "amount",
+	"Phone":         "phone",
+	"CustomerID":    "customer_id",
+	"Note":          "note",
+	"CallbackURL":   "callback_url",
+	"ReturnType":    "return_type",
+	"ExpireMinutes": "expire_minutes",
+}
+
+// jsonFieldNames returns err with the fields of its ValidationError renamed
+// after the request body.
+func jsonFieldNames(err error) error {
+	var validation *types.ValidationError
+	if !errors.As(err, &validation) {
+		return err
+	}
+	fields := make([]*types.FieldError, len(validation.Fields))
+	for i, field := range validation.Fields {
+		name := field.Field
+		if renamed, ok := invoiceFields[name]; ok {
+			name = renamed
+		}
+		fields[i] = &types.FieldError{Field: name, Message: field.Message}
+	}
+	return &types.ValidationError{Fields: fields}
+}
+
+// writeJSON encodes v before writing the status, so a value that cannot be
+// encoded, e.g. a raw provider payload holding NaN, becomes a 502 instead of
+// a truncated success.
+func (s *Server) writeJSON(w http.ResponseWriter, req *http.Request, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.input.Logger.ErrorContext(req.Context(), "encode response", "method", req.Method, "path", req.URL.Path, "error", err)
+		status = http.StatusBadGateway
+		data, _ = json.Marshal(ErrorResponse{Error: ErrorBody{
+			Code:    types.ErrorCodeUnknown,
+			Message: types.UserMessage(err, types.ParseLanguage(req.Header.Get("Accept-Language"))),
+			Detail:  "encode response: " + err.Error(),
+		}})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		s.input.Logger.WarnContext(req.Context(), "write response", "method", req.Method, "path", req.URL.Path, "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+	"github.com/techpartners-asia/payments-gateway/sdk/webhook"
+)
+
+func newTestServer(t *testing.T, lookup webhook.Lookup) *Server {
+	t.Helper()
+	server, err := New(Input{
+		SDK: sdk.NewSDK(sdk.Input{Providers: map[types.PaymentType]types.PaymentProvider{
+			"fake": &fakeprovider.Provider{
+				ProviderInfo: types.ProviderInfo{DisplayName: "Fake", CheckRequiresAmount: true},
+				Answers:      fakeprovider.Statuses(types.PaymentStatusPaid),
+			},
+			// Answers with a payload JSON cannot encode.
+			"nan": &fakeprovider.Provider{Raw: math.NaN()},
+		}}),
+		APIKeys: []string{"key"},
+		Lookup:  lookup,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server
+}
+
+func TestCheckInvoice(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantField  string
+	}{
+		{name: "with amount", path: "/v1/invoices/fake/order-1?amount=1500.50", wantStatus: http.StatusOK},
+		{name: "missing amount", path: "/v1/invoices/fake/order-1", wantStatus: http.StatusBadRequest, wantField: "amount"},
+		{name: "malformed amount", path: "/v1/invoices/fake/order-1?amount=abc", wantStatus: http.StatusBadRequest, wantField: "amount"},
+		{name: "unencodable result", path: "/v1/invoices/nan/order-1?amount=100", wantStatus: http.StatusBadGateway},
+	}
+	server := newTestServer(t, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", "key")
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK {
+				var result types.CheckInvoiceResult
+				if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || !result.IsPaid {
+					t.Fatalf("body = %s (%v), want a paid result", rec.Body, err)
+				}
+				return
+			}
+
+			var body ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body = %s is not an ErrorResponse: %v", rec.Body, err)
+			}
+			if tt.wantField != "" && (len(body.Error.Fields) != 1 || body.Error.Fields[0].Field != tt.wantField) {
+				t.Errorf("fields = %+v, want %s", body.Error.Fields, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestCallbacksNeedLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		lookup     webhook.Lookup
+		wantStatus int
+	}{
+		{name: "without lookup", wantStatus: http.StatusNotFound},
+		// The invoice is unknown to the empty store.
+		{name: "with lookup", lookup: webhook.StoreLookup(store.NewMemory()), wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.lookup)
+			rec := httptest.NewRecorder()
+			server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/callbacks/qpay?uid=order-1", nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// IdempotencyRecord is what an IdempotencyStore keeps for one (Type, UID).
+type IdempotencyRecord struct {
+	Type      types.PaymentType
+	UID       string
+	Amount    types.Money
+	Result    *types.InvoiceResult // nil while the creation is in flight
+	CreatedAt time.Time
+}
+
+// IdempotencyStore remembers invoice creations so that a retried Create for
+// the same (Type, UID) returns the original result instead of opening a
+// second invoice. Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Reserve stores record unless one exists for its (Type, UID). It returns
+	// the existing record, or nil when record was stored.
+	Reserve(ctx context.Context, record IdempotencyRecord) (*IdempotencyRecord, error)
+	// Complete attaches the provider result to a reserved record.
+	Complete(ctx context.Context, paymentType types.PaymentType, uid string, result *types.InvoiceResult) error
+	// Release drops a reservation whose creation failed so it can be retried.
+	// It is not called when the creation may have reached the provider; call
+	// it yourself once the provider shows no invoice was opened for the UID.
+	// Stores should also let such reservations lapse, as
+	// MemoryIdempotencyStore does after ReservationTTL.
+	Release(ctx context.Context, paymentType types.PaymentType, uid string) error
+}
+
+// DefaultReservationTTL is how long MemoryIdempotencyStore keeps a
+// reservation that never got a result.
+const DefaultReservationTTL = 30 * time.Minute
+
+type idempotencyKey struct {
+	paymentType types.PaymentType
+	uid         string
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore for a single process.
+// Completed records are kept until the process exits.
+type MemoryIdempotencyStore struct {
+	// ReservationTTL bounds how long a reservation without a result, i.e. a
+	// creation whose outcome is unknown, blocks its (Type, UID). After it a
+	// Create for the UID calls the provider again. Zero uses
+	// DefaultReservationTTL; set it before first use.
+	ReservationTTL time.Duration
+
+	mu      sync.Mutex
+	records map[idempotencyKey]IdempotencyRecord
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{records: make(map[idempotencyKey]IdempotencyRecord)}
+}
+
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, record IdempotencyRecord) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ttl := s.ReservationTTL
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+	key := idempotencyKey{record.Type, record.UID}
+	if existing, ok := s.records[key]; ok && (existing.Result != nil || record.CreatedAt.Sub(existing.CreatedAt) < ttl) {
+		return &existing, nil
+	}
+	s.records[key] = record
+	return nil, nil
+}
+
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, paymentType types.PaymentType, uid string, result *types.InvoiceResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := idempotencyKey{paymentType, uid}
+	record, ok := s.records[key]
+	if !ok {
+		return fmt.Errorf("no idempotency reservation for %s %s", paymentType, uid)
+	}
+	record.Result = result
+	s.records[key] = record
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, paymentType types.PaymentType, uid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, idempotencyKey{paymentType, uid})
+	return nil
+}
+
+// createIdempotent calls create at most once per (Type, UID). A replay with
+// the same amount returns the stored result; a different amount, or a replay
+// while the first call is still running, is rejected. A creation that
+// certainly opened no invoice (see releasable) is released, so a retry
+// reaches the provider again. Any other failure, such as a timeout after the
+// request was sent, keeps its reservation: the provider may have opened the
+// invoice, so calling it again could open a second one.
+func createIdempotent(ctx context.Context, store IdempotencyStore, input types.InvoiceInput, create func() (*types.InvoiceResult, error)) (*types.InvoiceResult, error) {
+	existing, err := store.Reserve(ctx, IdempotencyRecord{
+		Type:      input.Type,
+		UID:       input.UID,
+		Amount:    input.Amount,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("idempotency reserve: %w", err)
+	}
+
+	if existing != nil {
+		if !existing.Amount.SameCurrency(input.Amount) || existing.Amount.Cmp(input.Amount) != 0 {
+			return nil, &types.ProviderError{
+				Type:      input.Type,
+				Operation: types.OperationCreate,
+				Kind:      types.ErrIdempotencyConflict,
+				Message:   fmt.Sprintf("uid %s was created with amount %s, got %s", input.UID, existing.Amount, input.Amount),
+			}
+		}
+		if existing.Result == nil {
+			return nil, &types.ProviderError{
+				Type:      input.Type,
+				Operation: types.OperationCreate,
+				Kind:      types.ErrDuplicate,
+				Message:   fmt.Sprintf("creation for uid %s is in progress or its outcome is unknown", input.UID),
+			}
+		}
+		return existing.Result, nil
+	}
+
+	// Bookkeeping must outlive a caller deadline that cut the provider call short.
+	storeCtx := context.WithoutCancel(ctx)
+	// create returns a result with an error when the invoice exists but
+	// recording it failed; that still completes the reservation.
+	result, err := create()
+	if result == nil && !releasable(err) {
+		return nil, err
+	}
+	if result == nil {
+		if releaseErr := store.Release(storeCtx, input.Type, input.UID); releaseErr != nil {
+			return nil, fmt.Errorf("%w (idempotency release: %v)", err, releaseErr)
+		}
+		return nil, err
+	}
+	if completeErr := store.Complete(storeCtx, input.Type, input.UID, result); completeErr != nil {
+		// The invoice exists at the provider, so hand it back with the error.
+		err = errors.Join(err, fmt.Errorf("idempotency complete: %w", completeErr))
+	}
+	return result, err
+}
+
+// releasable reports whether a failed creation certainly opened no invoice:
+// the request never left (IsNotSent, or an open circuit), or the provider
+// answered it with a rejection. Transport failures after sending, abandoned
+// calls and duplicates reported by the provider are not releasable.
+func releasable(err error) bool {
+	if errors.Is(err, types.ErrOutcomeUnknown) {
+		return false
+	}
+	if IsNotSent(err) || errors.Is(err, types.ErrCircuitOpen) {
+		return true
+	}
+	return !errors.Is(err, types.ErrProviderUnavailable) && !errors.Is(err, types.ErrDuplicate)
+}
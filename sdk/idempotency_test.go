@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestCreateIdempotentFailedCreation(t *testing.T) {
+	providerErr := func(kind, err error) error {
+		return &types.ProviderError{Type: types.PaymentTypeQPay, Operation: types.OperationCreate, Kind: kind, Err: err}
+	}
+	tests := []struct {
+		name     string
+		err      error
+		released bool
+	}{
+		{name: "invalid input", err: providerErr(types.ErrInvalidInput, nil), released: true},
+		{name: "auth failed", err: providerErr(types.ErrAuthFailed, nil), released: true},
+		{name: "unclassified rejection", err: providerErr(nil, errors.New(`{"error":"INVOICE_CODE_INVALID"}`)), released: true},
+		{name: "not sent", err: providerErr(types.ErrProviderUnavailable, &net.OpError{Op: "dial", Err: errors.New("connection refused")}), released: true},
+		{name: "circuit open", err: providerErr(types.ErrProviderUnavailable, types.ErrCircuitOpen), released: true},
+		{name: "timeout after send", err: providerErr(types.ErrProviderUnavailable, fmt.Errorf("Post: %w", context.DeadlineExceeded))},
+		{name: "connection dropped", err: providerErr(types.ErrProviderUnavailable, io.ErrUnexpectedEOF)},
+		{name: "gateway timeout", err: providerErr(types.ErrProviderUnavailable, errors.New(`{"status":504}`))},
+		{name: "duplicate", err: providerErr(types.ErrDuplicate, nil)},
+		{name: "abandoned call", err: providerErr(types.ErrProviderUnavailable, fmt.Errorf("%w: %w", types.ErrOutcomeUnknown, context.DeadlineExceeded))},
+		{name: "library panic", err: providerErr(types.ErrProviderUnavailable, fmt.Errorf("%w: provider library panic", types.ErrOutcomeUnknown))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryIdempotencyStore()
+			input := types.InvoiceInput{Type: types.PaymentTypeQPay, UID: "order-1", Amount: types.MNT(1000)}
+
+			_, err := createIdempotent(context.Background(), store, input, func() (*types.InvoiceResult, error) {
+				return nil, tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("first create error = %v, want %v", err, tt.err)
+			}
+
+			calls := 0
+			_, err = createIdempotent(context.Background(), store, input, func() (*types.InvoiceResult, error) {
+				calls++
+				return &types.InvoiceResult{BankInvoiceID: "inv-1"}, nil
+			})
+			if tt.released {
+				if err != nil || calls != 1 {
+					t.Fatalf("replay after release: err = %v, calls = %d, want nil, 1", err, calls)
+				}
+				return
+			}
+			if !errors.Is(err, types.ErrDuplicate) || calls != 0 {
+				t.Fatalf("replay after kept reservation: err = %v, calls = %d, want ErrDuplicate, 0", err, calls)
+			}
+		})
+	}
+}
+
+func TestMemoryIdempotencyStoreReservationTTL(t *testing.T) {
+	store := &MemoryIdempotencyStore{ReservationTTL: time.Minute, records: make(map[idempotencyKey]IdempotencyRecord)}
+	ctx := context.Background()
+	start := time.Now()
+	reserve := func(uid string, at time.Time) *IdempotencyRecord {
+		t.Helper()
+		existing, err := store.Reserve(ctx, IdempotencyRecord{Type: types.PaymentTypeQPay, UID: uid, Amount: types.MNT(1000), CreatedAt: at})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return existing
+	}
+
+	if reserve("pending", start) != nil {
+		t.Fatal("first reservation was not stored")
+	}
+	if reserve("pending", start.Add(59*time.Second)) == nil {
+		t.Fatal("reservation lapsed before its TTL")
+	}
+	if reserve("pending", start.Add(time.Minute)) != nil {
+		t.Fatal("reservation without a result outlived its TTL")
+	}
+
+	reserve("done", start)
+	if err := store.Complete(ctx, types.PaymentTypeQPay, "done", &types.InvoiceResult{BankInvoiceID: "inv-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if existing := reserve("done", start.Add(time.Hour)); existing == nil || existing.Result.BankInvoiceID != "inv-1" {
+		t.Fatalf("completed record = %+v, want it kept past the TTL", existing)
+	}
+}
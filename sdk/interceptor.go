@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Call describes one SDK operation passing through the interceptors.
+type Call struct {
+	Operation types.Operation
+	Type      types.PaymentType
+	UID       string
+	// Input is the operation input: types.InvoiceInput, CheckInvoiceInput,
+	// RefundInput or CancelInput. An interceptor may pass a modified copy of
+	// the same type to next.
+	Input any
+}
+
+// Handler runs a call and returns the operation result: *types.InvoiceResult,
+// *CheckInvoiceResult, *RefundResult or *CancelResult.
+type Handler func(ctx context.Context, call Call) (any, error)
+
+// Interceptor wraps every SDK operation for all providers. It can inspect or
+// change the call, observe the result, or answer without calling next.
+type Interceptor func(ctx context.Context, call Call, next Handler) (any, error)
+
+// Use appends interceptors to the chain. The first registered interceptor is
+// the outermost; those from Input.Interceptors come before any added here.
+func (s *sdk) Use(interceptors ...Interceptor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.interceptors = append(s.interceptors, interceptors...)
+}
+
+// intercept runs fn behind the interceptor chain.
+func intercept[In any, Out any](ctx context.Context, s *sdk, op types.Operation, paymentType types.PaymentType, uid string, input In, fn func(context.Context, In) (*Out, error)) (*Out, error) {
+	s.mu.RLock()
+	interceptors := s.interceptors
+	s.mu.RUnlock()
+	if len(interceptors) == 0 {
+		return fn(ctx, input)
+	}
+
+	handler := Handler(func(ctx context.Context, call Call) (any, error) {
+		input, ok := call.Input.(In)
+		if !ok {
+			return nil, fmt.Errorf("sdk: %s interceptor passed %T, want %T", op, call.Input, input)
+		}
+		return fn(ctx, input)
+	})
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, call Call) (any, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+
+	res, err := handler(ctx, Call{Operation: op, Type: paymentType, UID: uid, Input: input})
+	if res == nil {
+		return nil, err
+	}
+	out, ok := res.(*Out)
+	if !ok {
+		return nil, fmt.Errorf("sdk: %s interceptor returned %T, want %T", op, res, out)
+	}
+	return out, err
+}
@@ -0,0 +1,197 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// DefaultEnvPrefix prefixes every environment variable read by LoadEnv.
+const DefaultEnvPrefix = "PAYMENTS"
+
+// LoadOptions controls Load.
+type LoadOptions struct {
+	// File is an optional YAML (.yaml, .yml) or JSON (.json) config file.
+	File string
+	// EnvPrefix defaults to DefaultEnvPrefix.
+	EnvPrefix string
+	// LookupEnv defaults to os.LookupEnv.
+	LookupEnv func(key string) (string, bool)
+}
+
+// Load builds an Input from opts.File, then overrides it with any environment
+// variables that are set, and validates the result.
+//
+// Environment variables are named <prefix>_<PROVIDER>_<FIELD> after the yaml
+// keys of Input, e.g. PAYMENTS_QPAY_USERNAME, PAYMENTS_QPAY_INVOICE_CODE or
+// PAYMENTS_POCKET_TERMINAL_ID. Any variable may instead be given as
+// <name>_FILE holding the path of a file with the value, as mounted by
+// Kubernetes or Docker secrets.
+func Load(opts LoadOptions) (Input, error) {
+	var input Input
+	if opts.File != "" {
+		var err error
+		if input, err = LoadFile(opts.File); err != nil {
+			return Input{}, err
+		}
+	}
+
+	prefix := opts.EnvPrefix
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	lookup := opts.LookupEnv
+	if lookup == nil {
+		lookup = os.LookupEnv
+	}
+	if err := applyEnv(&input, prefix, lookup); err != nil {
+		return Input{}, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return Input{}, err
+	}
+	return input, nil
+}
+
+// LoadEnv builds an Input from environment variables only. It does not validate.
+func LoadEnv(prefix string) (Input, error) {
+	var input Input
+	if err := applyEnv(&input, prefix, os.LookupEnv); err != nil {
+		return Input{}, err
+	}
+	return input, nil
+}
+
+// LoadFile decodes a YAML or JSON config file, chosen by extension. Unknown
+// keys are rejected so typos do not silently disable a provider. It does not
+// validate.
+func LoadFile(path string) (Input, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Input{}, fmt.Errorf("read config file: %w", err)
+	}
+
+	var input Input
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&input)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&input)
+	default:
+		return Input{}, fmt.Errorf("unsupported config file extension: %q", ext)
+	}
+	if err != nil {
+		return Input{}, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return input, nil
+}
+
+// applyEnv overrides each provider field of input whose environment variable is set.
+func applyEnv(input *Input, prefix string, lookup func(string) (string, bool)) error {
+	sections := reflect.ValueOf(input).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		sectionField := sections.Type().Field(i)
+		section := sections.Field(i)
+		sectionKey := yamlKey(sectionField)
+		if section.Kind() != reflect.Struct || sectionKey == "" {
+			continue
+		}
+
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			key := envKey(prefix, sectionKey, yamlKey(field))
+			value, ok, err := lookupEnv(key, lookup)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			switch target := section.Field(j); target.Kind() {
+			case reflect.String:
+				target.SetString(value)
+			case reflect.Int64:
+				n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+				if err != nil {
+					return fmt.Errorf("%s: invalid integer %q", key, value)
+				}
+				target.SetInt(n)
+			default:
+				return fmt.Errorf("%s: unsupported field type %s", key, target.Kind())
+			}
+		}
+	}
+	return nil
+}
+
+// lookupEnv reads key, or the file named by key_FILE. Setting both is an error.
+func lookupEnv(key string, lookup func(string) (string, bool)) (string, bool, error) {
+	value, ok := lookup(key)
+	path, fileOK := lookup(key + "_FILE")
+	if !fileOK {
+		return value, ok, nil
+	}
+	if ok {
+		return "", false, fmt.Errorf("both %s and %s_FILE are set", key, key)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", key, err)
+	}
+	// Secret files usually end with a newline that is not part of the value.
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+func envKey(parts ...string) string {
+	return strings.ToUpper(strings.Join(parts, "_"))
+}
+
+func yamlKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// String prints the configured provider sections with credentials masked, so
+// a loaded Input is safe to log.
+func (input Input) String() string {
+	rv := reflect.ValueOf(input)
+	var parts []string
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		value := rv.Field(i)
+		if value.Kind() != reflect.Struct || value.IsZero() || yamlKey(field) == "" {
+			continue
+		}
+		parts = append(parts, field.Name+":"+types.RedactedString(value.Interface()))
+	}
+
+	if len(input.Providers) > 0 {
+		names := make([]string, 0, len(input.Providers))
+		for paymentType := range input.Providers {
+			names = append(names, string(paymentType))
+		}
+		sort.Strings(names)
+		parts = append(parts, "Providers:["+strings.Join(names, " ")+"]")
+	}
+	return "sdk.Input{" + strings.Join(parts, " ") + "}"
+}
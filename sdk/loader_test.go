@@ -0,0 +1,173 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// qpayYAML is a complete QPay section.
+const qpayYAML = `qpay:
+  username: merchant
+  password: file-password
+  endpoint: https://merchant.qpay.mn/v2
+  callback: https://shop.example/callback
+  invoice_code: SHOP_INVOICE
+  merchant_id: m-1
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	secret := writeFile(t, "secret", "s3cret\n")
+	tests := []struct {
+		name    string
+		env     map[string]string
+		check   func(Input) bool
+		wantErr string
+	}{
+		{
+			name:  "multi-word key",
+			env:   map[string]string{"PAYMENTS_QPAY_INVOICE_CODE": "SHOP_INVOICE"},
+			check: func(in Input) bool { return in.Qpay.InvoiceCode == "SHOP_INVOICE" },
+		},
+		{
+			name:  "integer",
+			env:   map[string]string{"PAYMENTS_POCKET_TERMINAL_ID": " 42 "},
+			check: func(in Input) bool { return in.Pocket.TerminalIDRaw == 42 },
+		},
+		{
+			name:  "file",
+			env:   map[string]string{"PAYMENTS_QPAY_PASSWORD_FILE": secret},
+			check: func(in Input) bool { return in.Qpay.Password == "s3cret" },
+		},
+		{
+			name:    "value and file",
+			env:     map[string]string{"PAYMENTS_QPAY_PASSWORD": "s3cret", "PAYMENTS_QPAY_PASSWORD_FILE": secret},
+			wantErr: "both PAYMENTS_QPAY_PASSWORD and PAYMENTS_QPAY_PASSWORD_FILE are set",
+		},
+		{
+			name:    "missing file",
+			env:     map[string]string{"PAYMENTS_QPAY_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")},
+			wantErr: "PAYMENTS_QPAY_PASSWORD_FILE",
+		},
+		{
+			name:    "bad integer",
+			env:     map[string]string{"PAYMENTS_POCKET_TERMINAL_ID": "12a"},
+			wantErr: `PAYMENTS_POCKET_TERMINAL_ID: invalid integer "12a"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input Input
+			err := applyEnv(&input, DefaultEnvPrefix, envLookup(tt.env))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(input) {
+				t.Fatalf("input = %+v, env not applied", input)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := writeFile(t, "payments.yaml", qpayYAML)
+	input, err := Load(LoadOptions{
+		File:      file,
+		EnvPrefix: "SHOP",
+		LookupEnv: envLookup(map[string]string{"SHOP_QPAY_PASSWORD": "env-password", "PAYMENTS_QPAY_USERNAME": "ignored"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if input.Qpay.Password != "env-password" || input.Qpay.Username != "merchant" {
+		t.Fatalf("qpay = %+v, want the file overridden by SHOP_ variables only", input.Qpay)
+	}
+
+	if _, err := Load(LoadOptions{LookupEnv: envLookup(map[string]string{"PAYMENTS_QPAY_USERNAME": "merchant"})}); err == nil {
+		t.Fatal("Load accepted an incomplete QPay section")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr bool
+	}{
+		{name: "yaml", file: "payments.yaml", content: qpayYAML},
+		{name: "json", file: "payments.json", content: `{"qpay": {"username": "merchant", "invoice_code": "SHOP_INVOICE"}}`},
+		{name: "unknown yaml key", file: "payments.yml", content: "qpay:\n  invoicecode: SHOP_INVOICE\n", wantErr: true},
+		{name: "unknown yaml section", file: "payments.yaml", content: "qpays:\n  username: merchant\n", wantErr: true},
+		{name: "unknown json key", file: "payments.json", content: `{"qpay": {"invoiceCode": "SHOP_INVOICE"}}`, wantErr: true},
+		{name: "unsupported extension", file: "payments.toml", content: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := LoadFile(writeFile(t, tt.file, tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadFile = %+v, want an error", input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if input.Qpay.Username != "merchant" || input.Qpay.InvoiceCode != "SHOP_INVOICE" {
+				t.Fatalf("qpay = %+v, want merchant and SHOP_INVOICE", input.Qpay)
+			}
+		})
+	}
+}
+
+func TestInputString(t *testing.T) {
+	input := Input{
+		Qpay:    types.QpayAdapter{Username: "merchant", Password: "hunter2", InvoiceCode: "SHOP_INVOICE"},
+		TokiPay: types.TokipayAdapter{APIKey: "toki-key", MerchantID: "m-1"},
+		Providers: map[types.PaymentType]types.PaymentProvider{
+			"zeta":  &fakeprovider.Provider{},
+			"alpha": &fakeprovider.Provider{},
+		},
+	}
+	got := input.String()
+	for _, secret := range []string{"hunter2", "toki-key"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("String() = %s, leaks %q", got, secret)
+		}
+	}
+	for _, want := range []string{`Username:"merchant"`, "Password:" + types.Redacted, "APIKey:" + types.Redacted, `IMAPIKey:""`, "Providers:[alpha zeta]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %s, want it to contain %s", got, want)
+		}
+	}
+	if strings.Contains(got, "StorePay") {
+		t.Errorf("String() = %s, want empty sections left out", got)
+	}
+}
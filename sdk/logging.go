@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// providerCall runs one provider request behind the circuit breaker and logs
+// it when Input.Logger is set.
+func providerCall[T any](ctx context.Context, s *sdk, paymentType types.PaymentType, op types.Operation, uid string, request any, fn func() (T, error)) (T, error) {
+	return guardCall(s, paymentType, op, func() (T, error) {
+		return logCall(ctx, s, paymentType, op, uid, request, fn)
+	})
+}
+
+// logCall records fn as one provider request. Successful calls are logged at
+// Info, failures at Warn or, for outages and auth failures, at Error. The
+// redacted request and response payloads are only added at Debug.
+func logCall[T any](ctx context.Context, s *sdk, paymentType types.PaymentType, op types.Operation, uid string, request any, fn func() (T, error)) (T, error) {
+	if s.logger == nil {
+		return fn()
+	}
+
+	start := time.Now()
+	val, err := fn()
+	latency := time.Since(start)
+
+	attrs := []slog.Attr{
+		slog.String("operation", string(op)),
+		slog.String("provider", string(paymentType)),
+		slog.String("uid", uid),
+		slog.Duration("latency", latency),
+	}
+	level := slog.LevelInfo
+	if err != nil {
+		level = logLevel(err)
+		outcome := "failure"
+		if errors.Is(err, types.ErrOutcomeUnknown) {
+			// The call was abandoned, not refused; the provider may still act on it.
+			outcome = "unknown"
+		}
+		attrs = append(attrs,
+			slog.String("outcome", outcome),
+			slog.String("error_code", string(types.ErrorCodeOf(err))),
+			slog.String("error", s.redactor.String(err.Error())),
+		)
+	} else {
+		attrs = append(attrs, slog.String("outcome", "success"))
+		if result, ok := any(val).(*types.CheckInvoiceResult); ok && result != nil {
+			attrs = append(attrs, slog.String("status", string(result.Status)))
+		}
+	}
+	if s.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.Any("request", s.redactor.Value(request)))
+		if err == nil {
+			attrs = append(attrs, slog.Any("response", s.redactor.Value(val)))
+		}
+	}
+
+	s.logger.LogAttrs(ctx, level, "payment provider call", attrs...)
+	return val, err
+}
+
+func logLevel(err error) slog.Level {
+	if errors.Is(err, types.ErrProviderUnavailable) || errors.Is(err, types.ErrAuthFailed) || types.ErrorCodeOf(err) == types.ErrorCodeUnknown {
+		return slog.LevelError
+	}
+	return slog.LevelWarn
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestLogCallRedacts(t *testing.T) {
+	const password = "qpay-merchant-password"
+	secrets := []string{password, "toki-api-key", "tok-123", "4000-00XX-XXXX-0000"}
+
+	for _, level := range []slog.Level{slog.LevelInfo, slog.LevelDebug} {
+		t.Run(level.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			provider := &fakeprovider.Provider{
+				// The provider echoes the credentials it was called with.
+				CreateErrs: []error{errors.New("login merchant:" + password + " rejected")},
+				Raw: map[string]any{
+					"access_token": "tok-123",
+					"login":        "merchant:" + password,
+					"body":         `{"card_number": "4000-00XX-XXXX-0000"}`,
+				},
+			}
+			s := NewSDK(Input{
+				Qpay:      types.QpayAdapter{Username: "merchant", Password: password},
+				TokiPay:   types.TokipayAdapter{APIKey: "toki-api-key"},
+				Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider},
+				Retry:     map[types.Operation]RetryPolicy{types.OperationCreate: {MaxAttempts: 1}},
+				Logger:    slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level})),
+			})
+
+			input := types.InvoiceInput{Type: "fake", UID: "order-1", Amount: types.MNT(100), Note: "paid with toki-api-key"}
+			if _, err := s.Create(input); err == nil {
+				t.Fatal("first create succeeded, want the provider error")
+			}
+			input.UID = "order-2"
+			if _, err := s.Create(input); err != nil {
+				t.Fatal(err)
+			}
+
+			logs := buf.String()
+			if n := strings.Count(logs, "payment provider call"); n != 2 {
+				t.Fatalf("logged %d calls, want 2:\n%s", n, logs)
+			}
+			for _, secret := range secrets {
+				if strings.Contains(logs, secret) {
+					t.Errorf("logs contain %q:\n%s", secret, logs)
+				}
+			}
+			if got, want := strings.Contains(logs, `"response"`), level == slog.LevelDebug; got != want {
+				t.Errorf("response logged = %v, want %v:\n%s", got, want, logs)
+			}
+		})
+	}
+}
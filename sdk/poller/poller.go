@@ -0,0 +1,371 @@
+// Package poller checks pending invoices in the background until they settle,
+// for providers whose callbacks are missing or unreliable, and notifies
+// subscribers of every status change.
+package poller
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+const (
+	defaultConcurrency  = 4
+	defaultCheckTimeout = 30 * time.Second
+	defaultMaxAge       = 24 * time.Hour
+)
+
+// Cadence is the polling schedule of a provider: the first check runs
+// Initial after Watch, and each later delay grows by Multiplier up to Max.
+type Cadence struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// DefaultCadence is used for providers without an entry in Input.Cadence
+// or DefaultCadences.
+var DefaultCadence = Cadence{Initial: 5 * time.Second, Max: time.Minute, Multiplier: 1.5}
+
+// DefaultCadences holds the built-in per-provider schedules. StorePay loans
+// wait for a customer approval in the app, so they are polled less often.
+var DefaultCadences = map[types.PaymentType]Cadence{
+	types.PaymentTypeTokipay:  {Initial: 5 * time.Second, Max: 30 * time.Second, Multiplier: 1.5},
+	types.PaymentTypeSocial:   {Initial: 5 * time.Second, Max: 30 * time.Second, Multiplier: 1.5},
+	types.PaymentTypeSimple:   {Initial: 5 * time.Second, Max: time.Minute, Multiplier: 1.5},
+	types.PaymentTypeStorePay: {Initial: 15 * time.Second, Max: 2 * time.Minute, Multiplier: 2},
+}
+
+// Job is an invoice to poll.
+type Job struct {
+	Check types.CheckInvoiceInput // input passed to SDK.CheckContext
+	// Status is the last known status, pending when empty. Events report
+	// changes from it.
+	Status types.PaymentStatus
+	// ExpiresAt stops polling once passed; zero means MaxAge after Watch.
+	ExpiresAt time.Time
+}
+
+// Event reports a status change or a failed check of a watched invoice.
+type Event struct {
+	Type   types.PaymentType
+	UID    string // Job.Check.UID
+	From   types.PaymentStatus
+	Status types.PaymentStatus // equals From when Err is set
+	// Result is the check that observed the change. For an invoice reported
+	// expired it is the check that still saw it open.
+	Result *types.CheckInvoiceResult
+	// Err is set when the check failed; the invoice is checked again unless
+	// Stopped is set.
+	Err error
+	// Stopped reports that the invoice is no longer polled although it has
+	// no final status: a check past ExpiresAt failed with a non-transient
+	// error, or checks kept failing for MaxAge after ExpiresAt.
+	Stopped bool
+	At      time.Time
+}
+
+// Subscriber receives events. It is called from the polling goroutines, so it
+// must be safe for concurrent use and should return quickly.
+type Subscriber func(ctx context.Context, event Event)
+
+type Input struct {
+	SDK          sdk.SDK
+	Concurrency  int                           // maximum checks in flight, default 4
+	CheckTimeout time.Duration                 // per check, default 30s
+	MaxAge       time.Duration                 // expiry for jobs without ExpiresAt and retry limit past it, default 24h
+	Cadence      map[types.PaymentType]Cadence // overrides DefaultCadences
+}
+
+type jobKey struct {
+	paymentType types.PaymentType
+	uid         string
+}
+
+type job struct {
+	Job
+	key   jobKey
+	next  time.Time
+	delay time.Duration
+	index int
+}
+
+// Poller schedules checks of watched invoices. Jobs can be added before or
+// while Run is active.
+type Poller struct {
+	input Input
+
+	mu          sync.Mutex
+	jobs        map[jobKey]*job
+	queue       jobQueue
+	subscribers []Subscriber
+	wake        chan struct{}
+}
+
+func New(input Input) (*Poller, error) {
+	if input.SDK == nil {
+		return nil, errors.New("poller: SDK is required")
+	}
+	if input.Concurrency <= 0 {
+		input.Concurrency = defaultConcurrency
+	}
+	if input.CheckTimeout <= 0 {
+		input.CheckTimeout = defaultCheckTimeout
+	}
+	if input.MaxAge <= 0 {
+		input.MaxAge = defaultMaxAge
+	}
+	return &Poller{
+		input: input,
+		jobs:  make(map[jobKey]*job),
+		wake:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Subscribe registers fn for every later event.
+func (p *Poller) Subscribe(fn Subscriber) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.subscribers = append(p.subscribers, fn)
+}
+
+// Watch starts polling j, replacing any job for the same (Type, UID).
+func (p *Poller) Watch(j Job) {
+	if j.Status == "" {
+		j.Status = types.PaymentStatusPending
+	}
+	if j.ExpiresAt.IsZero() {
+		j.ExpiresAt = time.Now().Add(p.input.MaxAge)
+	}
+	cadence := p.cadence(j.Check.Type)
+
+	p.mu.Lock()
+	key := jobKey{j.Check.Type, j.Check.UID}
+	if old, ok := p.jobs[key]; ok && old.index >= 0 {
+		heap.Remove(&p.queue, old.index)
+	}
+	w := &job{Job: j, key: key, delay: cadence.Initial, next: time.Now().Add(cadence.Initial)}
+	p.jobs[key] = w
+	heap.Push(&p.queue, w)
+	p.mu.Unlock()
+
+	p.notify()
+}
+
+// Unwatch stops polling an invoice.
+func (p *Poller) Unwatch(paymentType types.PaymentType, uid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := jobKey{paymentType, uid}
+	if j, ok := p.jobs[key]; ok {
+		if j.index >= 0 {
+			heap.Remove(&p.queue, j.index)
+		}
+		delete(p.jobs, key)
+	}
+}
+
+// Len returns the number of watched invoices.
+func (p *Poller) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.jobs)
+}
+
+// Run polls due jobs until ctx is done, then waits for checks in flight and
+// returns ctx.Err().
+func (p *Poller) Run(ctx context.Context) error {
+	slots := make(chan struct{}, p.input.Concurrency)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		j, wait := p.due(time.Now())
+		if j != nil {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				p.requeue(j)
+				return ctx.Err()
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				p.poll(ctx, j)
+			}()
+			continue
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// due pops the next job whose time has come, or returns how long to wait.
+func (p *Poller) due(now time.Time) (*job, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue.Len() == 0 {
+		return nil, time.Hour
+	}
+	if next := p.queue[0].next; next.After(now) {
+		return nil, next.Sub(now)
+	}
+	return heap.Pop(&p.queue).(*job), 0
+}
+
+func (p *Poller) poll(ctx context.Context, j *job) {
+	checkCtx, cancel := context.WithTimeout(ctx, p.input.CheckTimeout)
+	result, err := p.input.SDK.CheckContext(checkCtx, j.Check)
+	cancel()
+	if ctx.Err() != nil {
+		p.requeue(j)
+		return
+	}
+
+	now := time.Now()
+	if err != nil {
+		// Never settle an invoice on a failed check: retry it unless it
+		// is past expiry and the failure will not go away.
+		stopped := !now.Before(j.ExpiresAt) && (!sdk.IsRetryable(err) || !now.Before(j.ExpiresAt.Add(p.input.MaxAge)))
+		p.publish(ctx, Event{Type: j.Check.Type, UID: j.Check.UID, From: j.Status, Status: j.Status, Result: result, Err: err, Stopped: stopped, At: now})
+		if stopped {
+			p.drop(j)
+			return
+		}
+	} else {
+		status := j.Status
+		if result != nil && result.Status != "" {
+			status = result.Status
+		}
+
+		switch {
+		case status != j.Status:
+			p.publish(ctx, Event{Type: j.Check.Type, UID: j.Check.UID, From: j.Status, Status: status, Result: result, At: now})
+			j.Status = status
+		case !now.Before(j.ExpiresAt) && !status.IsFinal():
+			// The check past expiry still saw the invoice open.
+			p.publish(ctx, Event{Type: j.Check.Type, UID: j.Check.UID, From: j.Status, Status: types.PaymentStatusExpired, Result: result, At: now})
+			j.Status = types.PaymentStatusExpired
+		}
+
+		if j.Status.IsFinal() {
+			p.drop(j)
+			return
+		}
+	}
+
+	cadence := p.cadence(j.Check.Type)
+	j.delay = time.Duration(float64(j.delay) * cadence.Multiplier)
+	if j.delay > cadence.Max {
+		j.delay = cadence.Max
+	}
+	j.next = now.Add(j.delay)
+	// Check once more right at expiry rather than well after it.
+	if now.Before(j.ExpiresAt) && j.next.After(j.ExpiresAt) {
+		j.next = j.ExpiresAt
+	}
+	p.requeue(j)
+}
+
+func (p *Poller) publish(ctx context.Context, event Event) {
+	p.mu.Lock()
+	subscribers := append([]Subscriber(nil), p.subscribers...)
+	p.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(ctx, event)
+	}
+}
+
+// requeue schedules j again unless it was unwatched or replaced meanwhile.
+func (p *Poller) requeue(j *job) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.jobs[j.key] != j {
+		return
+	}
+	heap.Push(&p.queue, j)
+	p.notify()
+}
+
+func (p *Poller) drop(j *job) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.jobs[j.key] == j {
+		delete(p.jobs, j.key)
+	}
+}
+
+func (p *Poller) notify() {
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (p *Poller) cadence(paymentType types.PaymentType) Cadence {
+	cadence, ok := p.input.Cadence[paymentType]
+	if !ok {
+		if cadence, ok = DefaultCadences[paymentType]; !ok {
+			cadence = DefaultCadence
+		}
+	}
+	if cadence.Initial <= 0 {
+		cadence.Initial = DefaultCadence.Initial
+	}
+	if cadence.Max < cadence.Initial {
+		cadence.Max = cadence.Initial
+	}
+	if cadence.Multiplier < 1 {
+		cadence.Multiplier = 1
+	}
+	return cadence
+}
+
+// jobQueue is a min-heap of jobs ordered by their next check time.
+type jobQueue []*job
+
+func (q jobQueue) Len() int           { return len(q) }
+func (q jobQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *jobQueue) Push(x any) {
+	j := x.(*job)
+	j.index = len(*q)
+	*q = append(*q, j)
+}
+
+func (q *jobQueue) Pop() any {
+	old := *q
+	j := old[len(old)-1]
+	old[len(old)-1] = nil
+	j.index = -1
+	*q = old[:len(old)-1]
+	return j
+}
@@ -0,0 +1,97 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// fakeSDK answers CheckContext with its fields; other methods are not used.
+type fakeSDK struct {
+	sdk.SDK
+	status types.PaymentStatus
+	err    error
+}
+
+func (s *fakeSDK) CheckContext(ctx context.Context, input types.CheckInvoiceInput) (*types.CheckInvoiceResult, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &types.CheckInvoiceResult{Status: s.status, IsPaid: s.status == types.PaymentStatusPaid}, nil
+}
+
+func TestPoll(t *testing.T) {
+	unavailable := &types.ProviderError{Type: types.PaymentTypeTokipay, Operation: types.OperationCheck, Kind: types.ErrProviderUnavailable, Message: "timeout"}
+	invalid := &types.ProviderError{Type: types.PaymentTypeTokipay, Operation: types.OperationCheck, Kind: types.ErrInvalidInput, Message: "unknown invoice"}
+
+	tests := []struct {
+		name      string
+		expiresIn time.Duration // relative to the check
+		status    types.PaymentStatus
+		err       error
+
+		wantEvent   bool
+		wantStatus  types.PaymentStatus
+		wantErr     bool
+		wantStopped bool
+		wantWatched bool
+	}{
+		{name: "still pending", expiresIn: time.Hour, status: types.PaymentStatusPending, wantWatched: true},
+		{name: "paid", expiresIn: time.Hour, status: types.PaymentStatusPaid, wantEvent: true, wantStatus: types.PaymentStatusPaid},
+		{name: "paid at expiry", expiresIn: -time.Second, status: types.PaymentStatusPaid, wantEvent: true, wantStatus: types.PaymentStatusPaid},
+		{name: "open at expiry", expiresIn: -time.Second, status: types.PaymentStatusPending, wantEvent: true, wantStatus: types.PaymentStatusExpired},
+		{name: "failed check", expiresIn: time.Hour, err: invalid, wantEvent: true, wantStatus: types.PaymentStatusPending, wantErr: true, wantWatched: true},
+		{name: "transient failure at expiry", expiresIn: -time.Second, err: unavailable, wantEvent: true, wantStatus: types.PaymentStatusPending, wantErr: true, wantWatched: true},
+		{name: "permanent failure at expiry", expiresIn: -time.Second, err: invalid, wantEvent: true, wantStatus: types.PaymentStatusPending, wantErr: true, wantStopped: true},
+		{name: "transient failure past max age", expiresIn: -2 * time.Hour, err: unavailable, wantEvent: true, wantStatus: types.PaymentStatusPending, wantErr: true, wantStopped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(Input{SDK: &fakeSDK{status: tt.status, err: tt.err}, MaxAge: time.Hour})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var events []Event
+			p.Subscribe(func(ctx context.Context, e Event) { events = append(events, e) })
+
+			p.Watch(Job{Check: types.CheckInvoiceInput{Type: types.PaymentTypeTokipay, UID: "order-1"}})
+			j, _ := p.due(time.Now().Add(time.Hour))
+			if j == nil {
+				t.Fatal("watched job is not queued")
+			}
+			j.ExpiresAt = time.Now().Add(tt.expiresIn)
+			p.poll(context.Background(), j)
+
+			if !tt.wantEvent {
+				if len(events) != 0 {
+					t.Fatalf("events = %+v, want none", events)
+				}
+			} else {
+				if len(events) != 1 {
+					t.Fatalf("events = %+v, want one", events)
+				}
+				e := events[0]
+				if e.From != types.PaymentStatusPending || e.Status != tt.wantStatus {
+					t.Errorf("event %s -> %s, want pending -> %s", e.From, e.Status, tt.wantStatus)
+				}
+				if (e.Err != nil) != tt.wantErr || e.Stopped != tt.wantStopped {
+					t.Errorf("event Err = %v, Stopped = %v, want error %v, stopped %v", e.Err, e.Stopped, tt.wantErr, tt.wantStopped)
+				}
+				if e.Err == nil && e.Result == nil {
+					t.Error("event without error has no Result")
+				}
+			}
+			if watched := p.Len() == 1; watched != tt.wantWatched {
+				t.Errorf("watched = %v, want %v", watched, tt.wantWatched)
+			}
+			if tt.wantWatched {
+				if next, _ := p.due(time.Now().Add(2 * time.Hour)); next == nil || !next.next.After(time.Now()) {
+					t.Error("job is not rescheduled in the future")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"errors"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func (s *sdk) AvailableProviders() []types.ProviderInfo {
+	health := make(map[types.PaymentType]bool)
+	for _, h := range s.Health() {
+		health[h.Type] = h.Available
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var infos []types.ProviderInfo
+	for _, paymentType := range s.registry.Types() {
+		if s.unconfigured[paymentType] {
+			continue
+		}
+		provider, ok := s.registry.Provider(paymentType)
+		if !ok {
+			continue
+		}
+		infos = append(infos, s.providerInfo(paymentType, provider, health[paymentType]))
+	}
+	return infos
+}
+
+func (s *sdk) providerInfo(paymentType types.PaymentType, provider types.PaymentProvider, available bool) types.ProviderInfo {
+	info := types.ProviderInfo{DisplayName: string(paymentType)}
+	if describer, ok := provider.(types.ProviderDescriber); ok {
+		info = describer.Info()
+	}
+	info.Type = paymentType
+	info.Available = available
+
+	if len(info.Operations) == 0 {
+		info.Operations = []types.Operation{types.OperationCreate, types.OperationCheck}
+		if _, ok := provider.(types.InvoiceRefunder); ok {
+			info.Operations = append(info.Operations, types.OperationRefund)
+		}
+		if _, ok := provider.(types.InvoiceCanceler); ok {
+			info.Operations = append(info.Operations, types.OperationCancel)
+		}
+	}
+
+	if override, ok := s.input.ProviderInfo[paymentType]; ok {
+		if override.DisplayName != "" {
+			info.DisplayName = override.DisplayName
+		}
+		if override.LogoURL != "" {
+			info.LogoURL = override.LogoURL
+		}
+		if !override.MinAmount.IsZero() {
+			info.MinAmount = override.MinAmount
+		}
+		if !override.MaxAmount.IsZero() {
+			info.MaxAmount = override.MaxAmount
+		}
+	}
+	return info
+}
+
+func (s *sdk) ValidateInvoice(input types.InvoiceInput) error {
+	input, err := resolveUID(input)
+	if err != nil {
+		return err
+	}
+	provider, err := s.provider(input.Type, types.OperationCreate)
+	if err != nil {
+		return err
+	}
+	return s.validateInvoice(provider, input)
+}
+
+// resolveUID fills input.UID from input.PaymentUID, reporting a mismatch as
+// an ErrInvalidInput error.
+func resolveUID(input types.InvoiceInput) (types.InvoiceInput, error) {
+	input, err := input.ResolveUID()
+	if err != nil {
+		return input, &types.ProviderError{Type: input.Type, Operation: types.OperationCreate, Kind: types.ErrInvalidInput, Err: err}
+	}
+	return input, nil
+}
+
+// validateCheck rejects a check without the amount the provider compares
+// payments against.
+func validateCheck(provider types.PaymentProvider, input types.CheckInvoiceInput) error {
+	if !input.Amount.IsZero() {
+		return nil
+	}
+	if describer, ok := provider.(types.ProviderDescriber); !ok || !describer.Info().CheckRequiresAmount {
+		return nil
+	}
+	var v types.Validator
+	v.Add("Amount", "is required to check "+string(input.Type)+" invoices")
+	return &types.ProviderError{Type: input.Type, Operation: types.OperationCheck, Kind: types.ErrInvalidInput, Err: v.Err()}
+}
+
+// validateInvoice returns an ErrInvalidInput error wrapping the
+// *types.ValidationError of every field that fails the provider's rules.
+// Custom providers are only checked when they describe themselves.
+func (s *sdk) validateInvoice(provider types.PaymentProvider, input types.InvoiceInput) error {
+	var errs []error
+	if _, ok := provider.(types.ProviderDescriber); ok {
+		errs = append(errs, input.Validate(s.providerInfo(input.Type, provider, true)))
+	}
+	if validator, ok := provider.(types.InvoiceValidator); ok {
+		errs = append(errs, validator.ValidateInvoice(input))
+	}
+
+	verr := &types.ValidationError{}
+	for _, err := range errs {
+		var fields *types.ValidationError
+		switch {
+		case err == nil:
+		case errors.As(err, &fields):
+			verr.Fields = append(verr.Fields, fields.Fields...)
+		default:
+			verr.Fields = append(verr.Fields, &types.FieldError{Field: "InvoiceInput", Message: err.Error()})
+		}
+	}
+	if len(verr.Fields) == 0 {
+		return nil
+	}
+	return &types.ProviderError{Type: input.Type, Operation: types.OperationCreate, Kind: types.ErrInvalidInput, Err: verr}
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestValidateInvoice(t *testing.T) {
+	tests := []struct {
+		name  string
+		input types.InvoiceInput
+		want  []string
+	}{
+		{name: "valid", input: types.InvoiceInput{UID: "order-1", Amount: types.MNT(100), Phone: "99119911"}},
+		{name: "info rules", input: types.InvoiceInput{UID: "order-1", Amount: types.MNT(99)}, want: []string{"Amount", "Phone"}},
+		{name: "provider rules", input: types.InvoiceInput{UID: "order-1", Amount: types.MNT(100), Phone: "99119911", ReturnType: "POST"}, want: []string{"ReturnType"}},
+		{name: "both", input: types.InvoiceInput{Amount: types.MNT(100), Phone: "99119911", ReturnType: "POST"}, want: []string{"UID", "ReturnType"}},
+		{name: "PaymentUID", input: types.InvoiceInput{PaymentUID: "order-1", Amount: types.MNT(100), Phone: "99119911"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeprovider.Provider{
+				ProviderInfo: types.ProviderInfo{DisplayName: "Fake", MinAmount: types.MNT(100), RequiresPhone: true},
+				Validate: func(input types.InvoiceInput) error {
+					var v types.Validator
+					if input.ReturnType != "" && input.ReturnType != "GET" {
+						v.Add("ReturnType", "must be GET")
+					}
+					return v.Err()
+				},
+			}
+			s := NewSDK(Input{Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider}})
+
+			input := tt.input
+			input.Type = "fake"
+			err := s.ValidateInvoice(input)
+			_, createErr := s.Create(input)
+			if len(provider.Creates()) != 0 && tt.want != nil {
+				t.Fatal("Create reached the provider with invalid input")
+			}
+			if tt.want == nil {
+				if err != nil || createErr != nil {
+					t.Fatalf("ValidateInvoice = %v, Create = %v, want nil", err, createErr)
+				}
+				return
+			}
+
+			var verr *types.ValidationError
+			if !errors.Is(err, types.ErrInvalidInput) || !errors.As(err, &verr) {
+				t.Fatalf("err = %v, want ErrInvalidInput with a ValidationError", err)
+			}
+			var got []string
+			for _, field := range verr.Fields {
+				got = append(got, field.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invalid fields = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(createErr, types.ErrInvalidInput) {
+				t.Errorf("Create err = %v, want ErrInvalidInput", createErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// recordCreate saves a created invoice to the configured store. A store
+// failure is returned alongside the result, since the invoice exists at the
+// provider either way.
+func (s *sdk) recordCreate(ctx context.Context, input types.InvoiceInput, result *types.InvoiceResult) error {
+	if s.input.Store == nil {
+		return nil
+	}
+	invoice := store.NewInvoice(input, result, time.Now())
+	if err := s.input.Store.SaveInvoice(context.WithoutCancel(ctx), invoice); err != nil {
+		return fmt.Errorf("record invoice %s: %w", input.UID, err)
+	}
+	return nil
+}
+
+// recordCheck applies a check result to the stored invoice. Invoices that
+// were not created through this SDK are not in the store and are skipped.
+func (s *sdk) recordCheck(ctx context.Context, input types.CheckInvoiceInput, result *types.CheckInvoiceResult) error {
+	if s.input.Store == nil {
+		return nil
+	}
+	ctx = context.WithoutCancel(ctx)
+	invoice, err := s.input.Store.FindInvoice(ctx, input.Type, input.UID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("record check %s: %w", input.UID, err)
+	}
+	update := store.NewStatusUpdate(input.Type, invoice.UID, result, time.Now())
+	if _, err := s.input.Store.UpdateStatus(ctx, update); err != nil {
+		return fmt.Errorf("record check %s: %w", input.UID, err)
+	}
+	return nil
+}
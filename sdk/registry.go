@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Registry maps payment types to the providers that serve them. It is safe
+// for concurrent use, so providers can be registered or replaced at runtime.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[types.PaymentType]types.PaymentProvider
+}
+
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[types.PaymentType]types.PaymentProvider)}
+}
+
+// Register adds provider under paymentType, replacing any provider already
+// registered for it. A nil provider removes the registration.
+func (r *Registry) Register(paymentType types.PaymentType, provider types.PaymentProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if provider == nil {
+		delete(r.providers, paymentType)
+		return
+	}
+	r.providers[paymentType] = provider
+}
+
+func (r *Registry) Unregister(paymentType types.PaymentType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.providers, paymentType)
+}
+
+func (r *Registry) Provider(paymentType types.PaymentType) (types.PaymentProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider, ok := r.providers[paymentType]
+	return provider, ok
+}
+
+// Types returns the registered payment types in sorted order.
+func (r *Registry) Types() []types.PaymentType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	paymentTypes := make([]types.PaymentType, 0, len(r.providers))
+	for paymentType := range r.providers {
+		paymentTypes = append(paymentTypes, paymentType)
+	}
+	sort.Slice(paymentTypes, func(i, j int) bool { return paymentTypes[i] < paymentTypes[j] })
+	return paymentTypes
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// RetryPolicy controls how often a failed provider call is attempted again.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first; 1 or less disables retries
+	InitialBackoff time.Duration // delay before the second attempt
+	MaxBackoff     time.Duration // upper bound of a single delay
+	Multiplier     float64       // growth of the delay per attempt
+	Jitter         float64       // random spread of each delay, 0.2 means ±20%
+	// Retryable reports whether err is worth another attempt; nil uses
+	// IsRetryable, or for Create the checks described at Input.Retry.
+	Retryable func(err error) bool
+}
+
+// DefaultRetryPolicy is applied to Create and Check when Input.Retry has no
+// entry for them.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// IsRetryable reports whether err is a transient provider failure: a
+// timeout, network error or provider outage. Calls rejected by an open
+// circuit breaker and calls abandoned because ctx ended are not retried.
+// Other failures with an unknown outcome, such as a provider library panic,
+// are: retryCreate excludes them for Create, where a second attempt could
+// open a second invoice.
+func IsRetryable(err error) bool {
+	return errors.Is(err, types.ErrProviderUnavailable) &&
+		!errors.Is(err, types.ErrCircuitOpen) &&
+		!errors.Is(err, context.Canceled) &&
+		!(errors.Is(err, types.ErrOutcomeUnknown) && errors.Is(err, context.DeadlineExceeded))
+}
+
+// IsNotSent reports whether err proves the request never reached the
+// provider: connecting to it, or resolving its host, failed.
+func IsNotSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryPolicy returns the policy for op, or false when op is not retried.
+func (s *sdk) retryPolicy(op types.Operation) (RetryPolicy, bool) {
+	policy, ok := s.input.Retry[op]
+	if !ok {
+		if op == types.OperationRefund || op == types.OperationCancel {
+			return RetryPolicy{}, false
+		}
+		policy = DefaultRetryPolicy
+	}
+	return policy, policy.MaxAttempts > 1
+}
+
+// retryCall runs fn under the retry policy s has for op.
+func retryCall[T any](ctx context.Context, s *sdk, op types.Operation, fn func() (T, error)) (T, error) {
+	policy, ok := s.retryPolicy(op)
+	if !ok {
+		return fn()
+	}
+	return retry(ctx, policy, fn)
+}
+
+// retryCreate runs a provider Create under the Create retry policy. A
+// timeout or dropped connection leaves open whether the provider created the
+// invoice, and sending it again could open a second one. Unless the policy
+// sets Retryable, Create is therefore only retried when the request never
+// reached the provider, or when the provider reports DedupesCreate. Abandoned
+// calls (types.ErrOutcomeUnknown) are never retried.
+func retryCreate(ctx context.Context, s *sdk, provider types.PaymentProvider, fn func() (*types.InvoiceResult, error)) (*types.InvoiceResult, error) {
+	policy, ok := s.retryPolicy(types.OperationCreate)
+	if !ok {
+		return fn()
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = func(err error) bool { return IsRetryable(err) && IsNotSent(err) }
+		if describer, ok := provider.(types.ProviderDescriber); ok && describer.Info().DedupesCreate {
+			retryable = IsRetryable
+		}
+	}
+	policy.Retryable = func(err error) bool {
+		return !errors.Is(err, types.ErrOutcomeUnknown) && retryable(err)
+	}
+	return retry(ctx, policy, fn)
+}
+
+// retry calls fn until it succeeds, returns a non-retryable error, the
+// attempts are used up or ctx is done.
+func retry[T any](ctx context.Context, policy RetryPolicy, fn func() (T, error)) (T, error) {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	var prevErr error
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		val, err := fn()
+		if prevErr != nil && errors.Is(err, types.ErrCircuitOpen) {
+			// The breaker opened on our own failures; report the real cause.
+			return val, prevErr
+		}
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return val, err
+		}
+		prevErr = err
+
+		timer := time.NewTimer(jitter(backoff, policy.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return val, err
+		case <-timer.C:
+		}
+
+		backoff = time.Duration(float64(backoff) * max(policy.Multiplier, 1))
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if d <= 0 || fraction <= 0 {
+		return max(d, 0)
+	}
+	spread := float64(d) * min(fraction, 1)
+	return time.Duration(float64(d) - spread + rand.Float64()*2*spread)
+}
@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func unavailable(err error) error {
+	return &types.ProviderError{Type: "fake", Kind: types.ErrProviderUnavailable, Err: err}
+}
+
+func TestIsNotSent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "dial", err: unavailable(&net.OpError{Op: "dial", Err: errors.New("connection refused")}), want: true},
+		{name: "dns", err: unavailable(&net.DNSError{Err: "no such host", Name: "merchant.qpay.mn"}), want: true},
+		{name: "read", err: unavailable(&net.OpError{Op: "read", Err: errors.New("connection reset by peer")})},
+		{name: "timeout", err: unavailable(context.DeadlineExceeded)},
+		{name: "plain", err: errors.New("bad request")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotSent(tt.err); got != tt.want {
+				t.Errorf("IsNotSent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	dial := unavailable(&net.OpError{Op: "dial", Err: errors.New("connection refused")})
+	timeout := unavailable(context.DeadlineExceeded)
+	abandoned := unavailable(errors.Join(types.ErrOutcomeUnknown, context.DeadlineExceeded))
+	panicked := unavailable(errors.Join(types.ErrOutcomeUnknown, errors.New("provider library panic")))
+	invalid := &types.ProviderError{Type: "fake", Kind: types.ErrInvalidInput}
+	open := unavailable(types.ErrCircuitOpen)
+
+	tests := []struct {
+		name      string
+		check     bool // call Check instead of Create
+		dedupes   bool
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "create succeeds", wantCalls: 1},
+		{name: "create not sent", errs: []error{dial}, wantCalls: 2},
+		{name: "create not sent twice", errs: []error{dial, dial}, wantCalls: 3},
+		{name: "create not sent every attempt", errs: []error{dial, dial, dial}, wantCalls: 3, wantErr: types.ErrProviderUnavailable},
+		{name: "create timeout", errs: []error{timeout}, wantCalls: 1, wantErr: types.ErrProviderUnavailable},
+		{name: "create timeout deduped", dedupes: true, errs: []error{timeout}, wantCalls: 2},
+		{name: "create abandoned deduped", dedupes: true, errs: []error{abandoned}, wantCalls: 1, wantErr: types.ErrOutcomeUnknown},
+		{name: "create panicked", errs: []error{panicked}, wantCalls: 1, wantErr: types.ErrOutcomeUnknown},
+		{name: "create panicked deduped", dedupes: true, errs: []error{panicked}, wantCalls: 1, wantErr: types.ErrOutcomeUnknown},
+		{name: "create invalid", errs: []error{invalid}, wantCalls: 1, wantErr: types.ErrInvalidInput},
+		{name: "create circuit open", errs: []error{open}, wantCalls: 1, wantErr: types.ErrCircuitOpen},
+		{name: "check timeout", check: true, errs: []error{timeout}, wantCalls: 2},
+		{name: "check timeout every attempt", check: true, errs: []error{timeout, timeout, timeout}, wantCalls: 3, wantErr: types.ErrProviderUnavailable},
+		{name: "check panicked", check: true, errs: []error{panicked}, wantCalls: 2},
+		{name: "check abandoned", check: true, errs: []error{abandoned}, wantCalls: 1, wantErr: types.ErrOutcomeUnknown},
+		{name: "check invalid", check: true, errs: []error{invalid}, wantCalls: 1, wantErr: types.ErrInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeprovider.Provider{ProviderInfo: types.ProviderInfo{DedupesCreate: tt.dedupes}}
+			s := NewSDK(Input{
+				Providers: map[types.PaymentType]types.PaymentProvider{"fake": provider},
+				Retry: map[types.Operation]RetryPolicy{
+					types.OperationCreate: {MaxAttempts: 3},
+					types.OperationCheck:  {MaxAttempts: 3},
+				},
+				Breaker: BreakerPolicy{Disabled: true},
+			})
+
+			var err error
+			calls := func() int { return len(provider.Creates()) }
+			if tt.check {
+				for _, err := range tt.errs {
+					provider.Answers = append(provider.Answers, fakeprovider.Answer{Err: err})
+				}
+				provider.Answers = append(provider.Answers, fakeprovider.Answer{})
+				_, err = s.Check(types.CheckInvoiceInput{Type: "fake", UID: "order-1"})
+				calls = func() int { return len(provider.Checks()) }
+			} else {
+				provider.CreateErrs = tt.errs
+				_, err = s.Create(types.InvoiceInput{Type: "fake", UID: "order-1", Amount: types.MNT(100)})
+			}
+
+			if got := calls(); got != tt.wantCalls {
+				t.Errorf("provider calls = %d, want %d", got, tt.wantCalls)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+type invoiceKey struct {
+	paymentType types.PaymentType
+	uid         string
+}
+
+// Memory is a Store for a single process, mainly for tests and development.
+type Memory struct {
+	mu          sync.RWMutex
+	invoices    map[invoiceKey]Invoice
+	transitions map[invoiceKey][]Transition
+}
+
+var _ Store = (*Memory)(nil)
+
+func NewMemory() *Memory {
+	return &Memory{
+		invoices:    make(map[invoiceKey]Invoice),
+		transitions: make(map[invoiceKey][]Transition),
+	}
+}
+
+func (m *Memory) SaveInvoice(ctx context.Context, invoice Invoice) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.invoices[invoiceKey{invoice.Type, invoice.UID}] = invoice
+	return nil
+}
+
+func (m *Memory) FindInvoice(ctx context.Context, paymentType types.PaymentType, id string) (*Invoice, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if invoice, ok := m.invoices[invoiceKey{paymentType, id}]; ok {
+		return &invoice, nil
+	}
+	for _, invoice := range m.invoices {
+		if invoice.Type == paymentType && invoice.BankInvoiceID != "" && invoice.BankInvoiceID == id {
+			return &invoice, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *Memory) UpdateStatus(ctx context.Context, update StatusUpdate) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := invoiceKey{update.Type, update.UID}
+	invoice, ok := m.invoices[key]
+	if !ok {
+		return false, ErrNotFound
+	}
+	from := invoice.Status
+	invoice.PaidAmount = update.PaidAmount
+	invoice.UpdatedAt = update.At
+	if from == update.Status {
+		m.invoices[key] = invoice
+		return false, nil
+	}
+
+	invoice.Status = update.Status
+	m.invoices[key] = invoice
+	m.transitions[key] = append(m.transitions[key], Transition{
+		Type:       update.Type,
+		UID:        update.UID,
+		From:       from,
+		To:         update.Status,
+		PaidAmount: update.PaidAmount,
+		Raw:        update.Raw,
+		At:         update.At,
+	})
+	return true, nil
+}
+
+func (m *Memory) Transitions(ctx context.Context, paymentType types.PaymentType, uid string) ([]Transition, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	transitions := m.transitions[invoiceKey{paymentType, uid}]
+	return append([]Transition(nil), transitions...), nil
+}
@@ -0,0 +1,253 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// SQLConfig configures a SQL store.
+type SQLConfig struct {
+	// TablePrefix is prepended to the table names, default "payment_".
+	TablePrefix string
+	// Placeholder is the bind parameter style of the driver: "?" (SQLite,
+	// MySQL; the default) or "$" for numbered PostgreSQL parameters.
+	Placeholder string
+}
+
+// SQL is a Store over database/sql. The schema only uses portable types, so
+// the same store runs on SQLite, PostgreSQL, MariaDB and MySQL (with
+// parseTime=true); MySQL lacks CREATE INDEX IF NOT EXISTS, so create its
+// schema from the Migrate statements instead of calling Migrate.
+type SQL struct {
+	db          *sql.DB
+	invoices    string
+	transitions string
+	dollar      bool
+}
+
+var _ Store = (*SQL)(nil)
+
+func NewSQL(db *sql.DB, config SQLConfig) *SQL {
+	prefix := config.TablePrefix
+	if prefix == "" {
+		prefix = "payment_"
+	}
+	return &SQL{
+		db:          db,
+		invoices:    prefix + "invoices",
+		transitions: prefix + "status_transitions",
+		dollar:      config.Placeholder == "$",
+	}
+}
+
+// Migrate creates the tables and indexes if they do not exist.
+func (s *SQL) Migrate(ctx context.Context) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS ` + s.invoices + ` (
+			payment_type VARCHAR(32) NOT NULL,
+			uid VARCHAR(255) NOT NULL,
+			bank_invoice_id VARCHAR(255) NOT NULL,
+			amount_minor BIGINT NOT NULL,
+			currency VARCHAR(3) NOT NULL,
+			status VARCHAR(32) NOT NULL,
+			paid_minor BIGINT NOT NULL,
+			paid_currency VARCHAR(3) NOT NULL,
+			expires_at TIMESTAMP NULL,
+			raw TEXT,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (payment_type, uid)
+		)`,
+		`CREATE INDEX IF NOT EXISTS ` + s.invoices + `_bank_invoice_id ON ` + s.invoices + ` (payment_type, bank_invoice_id)`,
+		`CREATE TABLE IF NOT EXISTS ` + s.transitions + ` (
+			payment_type VARCHAR(32) NOT NULL,
+			uid VARCHAR(255) NOT NULL,
+			from_status VARCHAR(32) NOT NULL,
+			to_status VARCHAR(32) NOT NULL,
+			paid_minor BIGINT NOT NULL,
+			paid_currency VARCHAR(3) NOT NULL,
+			raw TEXT,
+			created_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS ` + s.transitions + `_invoice ON ` + s.transitions + ` (payment_type, uid)`,
+	}
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("store: migrate: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) SaveInvoice(ctx context.Context, invoice Invoice) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: save invoice: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, s.rebind(`DELETE FROM `+s.invoices+` WHERE payment_type = ? AND uid = ?`),
+		invoice.Type, invoice.UID); err != nil {
+		return fmt.Errorf("store: save invoice: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO `+s.invoices+` (payment_type, uid, bank_invoice_id, amount_minor, currency, status, paid_minor, paid_currency, expires_at, raw, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		invoice.Type, invoice.UID, invoice.BankInvoiceID,
+		invoice.Amount.Minor, invoice.Amount.CurrencyCode(),
+		invoice.Status, invoice.PaidAmount.Minor, invoice.PaidAmount.CurrencyCode(),
+		nullTime(invoice.ExpiresAt), nullString(invoice.Raw),
+		invoice.CreatedAt.UTC(), invoice.UpdatedAt.UTC(),
+	); err != nil {
+		return fmt.Errorf("store: save invoice: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("store: save invoice: %w", err)
+	}
+	return nil
+}
+
+func (s *SQL) FindInvoice(ctx context.Context, paymentType types.PaymentType, id string) (*Invoice, error) {
+	// Prefer an exact UID match over a bank invoice id that happens to equal it.
+	row := s.db.QueryRowContext(ctx, s.rebind(`SELECT payment_type, uid, bank_invoice_id, amount_minor, currency, status, paid_minor, paid_currency, expires_at, raw, created_at, updated_at
+		FROM `+s.invoices+` WHERE payment_type = ? AND (uid = ? OR bank_invoice_id = ?)
+		ORDER BY CASE WHEN uid = ? THEN 0 ELSE 1 END LIMIT 1`),
+		paymentType, id, id, id)
+
+	var (
+		invoice   Invoice
+		expiresAt sql.NullTime
+		raw       sql.NullString
+	)
+	err := row.Scan(&invoice.Type, &invoice.UID, &invoice.BankInvoiceID,
+		&invoice.Amount.Minor, &invoice.Amount.Currency,
+		&invoice.Status, &invoice.PaidAmount.Minor, &invoice.PaidAmount.Currency,
+		&expiresAt, &raw, &invoice.CreatedAt, &invoice.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: find invoice: %w", err)
+	}
+	if expiresAt.Valid {
+		invoice.ExpiresAt = &expiresAt.Time
+	}
+	if raw.Valid {
+		invoice.Raw = []byte(raw.String)
+	}
+	return &invoice, nil
+}
+
+func (s *SQL) UpdateStatus(ctx context.Context, update StatusUpdate) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("store: update status: %w", err)
+	}
+	defer tx.Rollback()
+
+	var from types.PaymentStatus
+	err = tx.QueryRowContext(ctx, s.rebind(`SELECT status FROM `+s.invoices+` WHERE payment_type = ? AND uid = ?`),
+		update.Type, update.UID).Scan(&from)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, ErrNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("store: update status: %w", err)
+	}
+
+	// The status condition keeps concurrent checks from recording the same
+	// transition twice.
+	res, err := tx.ExecContext(ctx, s.rebind(`UPDATE `+s.invoices+` SET status = ?, paid_minor = ?, paid_currency = ?, updated_at = ?
+		WHERE payment_type = ? AND uid = ? AND status = ?`),
+		update.Status, update.PaidAmount.Minor, update.PaidAmount.CurrencyCode(), update.At.UTC(),
+		update.Type, update.UID, from)
+	if err != nil {
+		return false, fmt.Errorf("store: update status: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return false, nil
+	}
+
+	changed := from != update.Status
+	if changed {
+		if _, err := tx.ExecContext(ctx, s.rebind(`INSERT INTO `+s.transitions+` (payment_type, uid, from_status, to_status, paid_minor, paid_currency, raw, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+			update.Type, update.UID, from, update.Status,
+			update.PaidAmount.Minor, update.PaidAmount.CurrencyCode(),
+			nullString(update.Raw), update.At.UTC(),
+		); err != nil {
+			return false, fmt.Errorf("store: update status: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("store: update status: %w", err)
+	}
+	return changed, nil
+}
+
+func (s *SQL) Transitions(ctx context.Context, paymentType types.PaymentType, uid string) ([]Transition, error) {
+	rows, err := s.db.QueryContext(ctx, s.rebind(`SELECT payment_type, uid, from_status, to_status, paid_minor, paid_currency, raw, created_at
+		FROM `+s.transitions+` WHERE payment_type = ? AND uid = ? ORDER BY created_at`),
+		paymentType, uid)
+	if err != nil {
+		return nil, fmt.Errorf("store: transitions: %w", err)
+	}
+	defer rows.Close()
+
+	var transitions []Transition
+	for rows.Next() {
+		var (
+			t   Transition
+			raw sql.NullString
+		)
+		if err := rows.Scan(&t.Type, &t.UID, &t.From, &t.To, &t.PaidAmount.Minor, &t.PaidAmount.Currency, &raw, &t.At); err != nil {
+			return nil, fmt.Errorf("store: transitions: %w", err)
+		}
+		if raw.Valid {
+			t.Raw = []byte(raw.String)
+		}
+		transitions = append(transitions, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: transitions: %w", err)
+	}
+	return transitions, nil
+}
+
+// rebind rewrites ? placeholders as $1, $2, ... for PostgreSQL.
+func (s *SQL) rebind(query string) string {
+	if !s.dollar {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func nullString(raw []byte) sql.NullString {
+	if raw == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(raw), Valid: true}
+}
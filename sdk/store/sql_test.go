@@ -0,0 +1,31 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQL(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Every connection to :memory: opens its own empty database.
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { db.Close() })
+
+		st := NewSQL(db, SQLConfig{})
+		for range 2 {
+			if err := st.Migrate(context.Background()); err != nil {
+				t.Fatalf("Migrate: %v", err)
+			}
+		}
+		return st
+	})
+}
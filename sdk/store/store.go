@@ -0,0 +1,117 @@
+// Package store persists invoices created through the SDK together with the
+// status transitions and raw provider payloads observed by Check.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// ErrNotFound is returned when no invoice matches a lookup.
+var ErrNotFound = errors.New("store: invoice not found")
+
+// Invoice is one invoice created through the SDK.
+type Invoice struct {
+	Type          types.PaymentType   `json:"type"`
+	UID           string              `json:"uid"`
+	BankInvoiceID string              `json:"bank_invoice_id"`
+	Amount        types.Money         `json:"amount"`
+	Status        types.PaymentStatus `json:"status"`
+	PaidAmount    types.Money         `json:"paid_amount"`
+	ExpiresAt     *time.Time          `json:"expires_at,omitempty"`
+	Raw           json.RawMessage     `json:"raw,omitempty"` // create response
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// StatusUpdate is the outcome of one Check of an invoice.
+type StatusUpdate struct {
+	Type       types.PaymentType
+	UID        string
+	Status     types.PaymentStatus
+	PaidAmount types.Money
+	Raw        json.RawMessage // check response
+	At         time.Time
+}
+
+// Transition is a recorded status change of an invoice.
+type Transition struct {
+	Type       types.PaymentType   `json:"type"`
+	UID        string              `json:"uid"`
+	From       types.PaymentStatus `json:"from"`
+	To         types.PaymentStatus `json:"to"`
+	PaidAmount types.Money         `json:"paid_amount"`
+	Raw        json.RawMessage     `json:"raw,omitempty"`
+	At         time.Time           `json:"at"`
+}
+
+// Store persists invoices. Implementations must be safe for concurrent use.
+type Store interface {
+	// SaveInvoice inserts invoice, replacing a stored one with the same
+	// (Type, UID).
+	SaveInvoice(ctx context.Context, invoice Invoice) error
+	// FindInvoice returns the invoice whose UID or BankInvoiceID is id, so it
+	// accepts whichever identifier CheckInvoice was called with.
+	FindInvoice(ctx context.Context, paymentType types.PaymentType, id string) (*Invoice, error)
+	// UpdateStatus applies update and records a Transition when the status
+	// changes. It reports whether a transition was recorded.
+	UpdateStatus(ctx context.Context, update StatusUpdate) (bool, error)
+	// Transitions lists the status changes of an invoice, oldest first.
+	Transitions(ctx context.Context, paymentType types.PaymentType, uid string) ([]Transition, error)
+}
+
+// NewInvoice builds the record for a successful creation.
+func NewInvoice(input types.InvoiceInput, result *types.InvoiceResult, now time.Time) Invoice {
+	status := types.PaymentStatusPending
+	var paid types.Money
+	if result.IsPaid {
+		status, paid = types.PaymentStatusPaid, input.Amount
+	}
+	return Invoice{
+		Type:          input.Type,
+		UID:           input.UID,
+		BankInvoiceID: result.BankInvoiceID,
+		Amount:        input.Amount,
+		Status:        status,
+		PaidAmount:    paid,
+		ExpiresAt:     result.ExpiresAt,
+		Raw:           RawJSON(result.Raw),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// NewStatusUpdate builds the update for a successful check of uid.
+func NewStatusUpdate(paymentType types.PaymentType, uid string, result *types.CheckInvoiceResult, now time.Time) StatusUpdate {
+	status := result.Status
+	if status == "" {
+		status = types.PaymentStatusPending
+		if result.IsPaid {
+			status = types.PaymentStatusPaid
+		}
+	}
+	return StatusUpdate{
+		Type:       paymentType,
+		UID:        uid,
+		Status:     status,
+		PaidAmount: result.PaidAmount,
+		Raw:        RawJSON(result.Raw),
+		At:         now,
+	}
+}
+
+// RawJSON encodes a provider payload, returning nil when it cannot be encoded.
+func RawJSON(raw any) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// testStore runs the Store contract against the stores made by newStore.
+func testStore(t *testing.T, newStore func(t *testing.T) Store) {
+	ctx := context.Background()
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expires := created.Add(15 * time.Minute)
+	invoice := Invoice{
+		Type:          types.PaymentTypeQPay,
+		UID:           "order-1",
+		BankInvoiceID: "bank-1",
+		Amount:        types.MNT(1500),
+		Status:        types.PaymentStatusPending,
+		ExpiresAt:     &expires,
+		Raw:           json.RawMessage(`{"invoice_id":"bank-1"}`),
+		CreatedAt:     created,
+		UpdatedAt:     created,
+	}
+
+	t.Run("FindInvoice", func(t *testing.T) {
+		st := newStore(t)
+		if err := st.SaveInvoice(ctx, invoice); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"order-1", "bank-1"} {
+			got, err := st.FindInvoice(ctx, types.PaymentTypeQPay, id)
+			if err != nil {
+				t.Fatalf("FindInvoice(%q): %v", id, err)
+			}
+			if got.UID != invoice.UID || got.BankInvoiceID != invoice.BankInvoiceID || got.Status != invoice.Status {
+				t.Errorf("FindInvoice(%q) = %+v, want %+v", id, got, invoice)
+			}
+			if got.Amount.Cmp(invoice.Amount) != 0 || !got.Amount.SameCurrency(invoice.Amount) {
+				t.Errorf("FindInvoice(%q).Amount = %s, want %s", id, got.Amount, invoice.Amount)
+			}
+			if got.ExpiresAt == nil || !got.ExpiresAt.Equal(expires) {
+				t.Errorf("FindInvoice(%q).ExpiresAt = %v, want %v", id, got.ExpiresAt, expires)
+			}
+			if string(got.Raw) != string(invoice.Raw) {
+				t.Errorf("FindInvoice(%q).Raw = %s, want %s", id, got.Raw, invoice.Raw)
+			}
+		}
+
+		for _, tt := range []struct {
+			paymentType types.PaymentType
+			id          string
+		}{
+			{types.PaymentTypeQPay, "order-2"},
+			{types.PaymentTypeMonpay, "order-1"},
+			{types.PaymentTypeMonpay, "bank-1"},
+		} {
+			if _, err := st.FindInvoice(ctx, tt.paymentType, tt.id); !errors.Is(err, ErrNotFound) {
+				t.Errorf("FindInvoice(%s, %q) error = %v, want ErrNotFound", tt.paymentType, tt.id, err)
+			}
+		}
+	})
+
+	t.Run("SaveInvoiceReplaces", func(t *testing.T) {
+		st := newStore(t)
+		if err := st.SaveInvoice(ctx, invoice); err != nil {
+			t.Fatal(err)
+		}
+		replaced := invoice
+		replaced.BankInvoiceID = "bank-2"
+		if err := st.SaveInvoice(ctx, replaced); err != nil {
+			t.Fatal(err)
+		}
+		got, err := st.FindInvoice(ctx, types.PaymentTypeQPay, "order-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.BankInvoiceID != "bank-2" {
+			t.Errorf("BankInvoiceID = %q, want bank-2", got.BankInvoiceID)
+		}
+		if _, err := st.FindInvoice(ctx, types.PaymentTypeQPay, "bank-1"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("FindInvoice(bank-1) error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		st := newStore(t)
+		if err := st.SaveInvoice(ctx, invoice); err != nil {
+			t.Fatal(err)
+		}
+
+		updates := []struct {
+			status  types.PaymentStatus
+			paid    types.Money
+			changed bool
+		}{
+			{types.PaymentStatusPending, types.Money{}, false},
+			{types.PaymentStatusPartiallyPaid, types.MNT(500), true},
+			{types.PaymentStatusPartiallyPaid, types.MNT(700), false},
+			{types.PaymentStatusPaid, types.MNT(1500), true},
+		}
+		for i, u := range updates {
+			changed, err := st.UpdateStatus(ctx, StatusUpdate{
+				Type:       types.PaymentTypeQPay,
+				UID:        "order-1",
+				Status:     u.status,
+				PaidAmount: u.paid,
+				Raw:        json.RawMessage(`{"step":1}`),
+				At:         created.Add(time.Duration(i+1) * time.Minute),
+			})
+			if err != nil {
+				t.Fatalf("update %d: %v", i, err)
+			}
+			if changed != u.changed {
+				t.Errorf("update %d to %s changed = %v, want %v", i, u.status, changed, u.changed)
+			}
+		}
+
+		got, err := st.FindInvoice(ctx, types.PaymentTypeQPay, "order-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != types.PaymentStatusPaid || got.PaidAmount.Cmp(types.MNT(1500)) != 0 {
+			t.Errorf("invoice = %s paid %s, want paid 1500 MNT", got.Status, got.PaidAmount)
+		}
+		if want := created.Add(4 * time.Minute); !got.UpdatedAt.Equal(want) {
+			t.Errorf("UpdatedAt = %v, want %v", got.UpdatedAt, want)
+		}
+
+		transitions, err := st.Transitions(ctx, types.PaymentTypeQPay, "order-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []struct{ from, to types.PaymentStatus }{
+			{types.PaymentStatusPending, types.PaymentStatusPartiallyPaid},
+			{types.PaymentStatusPartiallyPaid, types.PaymentStatusPaid},
+		}
+		if len(transitions) != len(want) {
+			t.Fatalf("Transitions = %+v, want %d entries", transitions, len(want))
+		}
+		for i, w := range want {
+			if transitions[i].From != w.from || transitions[i].To != w.to {
+				t.Errorf("transition %d = %s -> %s, want %s -> %s", i, transitions[i].From, transitions[i].To, w.from, w.to)
+			}
+		}
+		if transitions[1].PaidAmount.Cmp(types.MNT(1500)) != 0 {
+			t.Errorf("transition PaidAmount = %s, want 1500 MNT", transitions[1].PaidAmount)
+		}
+	})
+
+	t.Run("UpdateStatusNotFound", func(t *testing.T) {
+		st := newStore(t)
+		_, err := st.UpdateStatus(ctx, StatusUpdate{Type: types.PaymentTypeQPay, UID: "missing", Status: types.PaymentStatusPaid, At: created})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("error = %v, want ErrNotFound", err)
+		}
+		transitions, err := st.Transitions(ctx, types.PaymentTypeQPay, "missing")
+		if err != nil || len(transitions) != 0 {
+			t.Errorf("Transitions = %v, %v, want none", transitions, err)
+		}
+	})
+}
+
+func TestMemory(t *testing.T) {
+	testStore(t, func(t *testing.T) Store { return NewMemory() })
+}
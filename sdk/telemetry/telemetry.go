@@ -0,0 +1,126 @@
+// Package telemetry instruments an SDK with OpenTelemetry: a span per
+// operation and metrics for invoices created, checks by reported status and
+// call latency. It is an sdk.Interceptor, so every provider is covered
+// without changes to the adapters.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/techpartners-asia/payments-gateway/sdk/telemetry"
+
+// Attribute keys set on spans and metrics.
+const (
+	AttrPaymentType = attribute.Key("payment.type")
+	AttrOperation   = attribute.Key("payment.operation")
+	AttrOutcome     = attribute.Key("payment.outcome") // "success" or "failure"
+	AttrErrorCode   = attribute.Key("payment.error_code")
+	AttrStatus      = attribute.Key("payment.status")
+	AttrUID         = attribute.Key("payment.uid") // spans only
+)
+
+type Input struct {
+	// Config is optional; its provider credentials are masked in the error
+	// messages recorded on spans.
+	Config         sdk.Input
+	TracerProvider trace.TracerProvider // defaults to otel.GetTracerProvider()
+	MeterProvider  metric.MeterProvider // defaults to otel.GetMeterProvider()
+}
+
+// Telemetry holds the instruments shared by the calls its Interceptor sees.
+type Telemetry struct {
+	redactor *types.Redactor
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	created  metric.Int64Counter
+	checks   metric.Int64Counter
+}
+
+func New(input Input) (*Telemetry, error) {
+	if input.TracerProvider == nil {
+		input.TracerProvider = otel.GetTracerProvider()
+	}
+	if input.MeterProvider == nil {
+		input.MeterProvider = otel.GetMeterProvider()
+	}
+
+	meter := input.MeterProvider.Meter(ScopeName)
+	t := &Telemetry{
+		redactor: types.NewRedactor(types.SecretValues(input.Config)...),
+		tracer:   input.TracerProvider.Tracer(ScopeName),
+	}
+
+	var err error
+	if t.duration, err = meter.Float64Histogram("payment.call.duration",
+		metric.WithDescription("Duration of SDK operations, including retries."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if t.created, err = meter.Int64Counter("payment.invoices.created",
+		metric.WithDescription("Create calls that returned an invoice."),
+		metric.WithUnit("{invoice}")); err != nil {
+		return nil, err
+	}
+	if t.checks, err = meter.Int64Counter("payment.checks",
+		metric.WithDescription("Successful checks by the invoice status they reported."),
+		metric.WithUnit("{check}")); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Interceptor traces and measures every operation. Register it first, e.g.
+// in sdk.Input.Interceptors, so its spans cover the other interceptors.
+func (t *Telemetry) Interceptor() sdk.Interceptor {
+	return func(ctx context.Context, call sdk.Call, next sdk.Handler) (any, error) {
+		begin := time.Now()
+		ctx, span := t.tracer.Start(ctx, "payment."+string(call.Operation),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(AttrPaymentType.String(string(call.Type)), AttrOperation.String(string(call.Operation)), AttrUID.String(call.UID)))
+		defer span.End()
+
+		res, err := next(ctx, call)
+
+		attrs := []attribute.KeyValue{AttrPaymentType.String(string(call.Type)), AttrOperation.String(string(call.Operation))}
+		if err != nil {
+			code := string(types.ErrorCodeOf(err))
+			attrs = append(attrs, AttrOutcome.String("failure"), AttrErrorCode.String(code))
+			span.RecordError(errors.New(t.redactor.String(err.Error())))
+			span.SetStatus(codes.Error, code)
+		} else {
+			attrs = append(attrs, AttrOutcome.String("success"))
+		}
+		if result, ok := res.(*types.CheckInvoiceResult); ok && result != nil && result.Status != "" {
+			attrs = append(attrs, AttrStatus.String(string(result.Status)))
+		}
+		span.SetAttributes(attrs...)
+		t.duration.Record(ctx, time.Since(begin).Seconds(), metric.WithAttributes(attrs...))
+
+		if err == nil {
+			switch result := res.(type) {
+			case *types.InvoiceResult:
+				if result != nil {
+					t.created.Add(ctx, 1, metric.WithAttributes(attrs...))
+				}
+			case *types.CheckInvoiceResult:
+				if result != nil {
+					t.checks.Add(ctx, 1, metric.WithAttributes(attrs...))
+				}
+			}
+		}
+		return res, err
+	}
+}
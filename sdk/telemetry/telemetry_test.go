@@ -0,0 +1,99 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func TestInterceptor(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	spans := tracetest.NewSpanRecorder()
+	tel, err := New(Input{
+		Config:         sdk.Input{Qpay: types.QpayAdapter{Password: "hunter2"}},
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := sdk.NewSDK(sdk.Input{
+		Providers: map[types.PaymentType]types.PaymentProvider{"fake": &fakeprovider.Provider{Answers: []fakeprovider.Answer{
+			{Status: types.PaymentStatusPaid},
+			{Status: types.PaymentStatusPaid},
+			{Err: &types.ProviderError{Type: "fake", Operation: types.OperationCheck, Kind: types.ErrInvalidInput, Message: "password=hunter2"}},
+		}}},
+		Interceptors: []sdk.Interceptor{tel.Interceptor()},
+	})
+	if _, err := s.Create(types.InvoiceInput{Type: "fake", UID: "order-1", Amount: types.MNT(100)}); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := s.Check(types.CheckInvoiceInput{Type: "fake", UID: "order-1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Check(types.CheckInvoiceInput{Type: "fake", UID: "bad"}); !errors.Is(err, types.ErrInvalidInput) {
+		t.Fatalf("err = %v, want ErrInvalidInput", err)
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	sums := make(map[string]int64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range agg.DataPoints {
+					status, _ := point.Attributes.Value(AttrStatus)
+					sums[m.Name+"/"+status.AsString()] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range agg.DataPoints {
+					sums[m.Name] += int64(point.Count)
+				}
+			}
+		}
+	}
+	want := map[string]int64{
+		"payment.invoices.created/": 1,
+		"payment.checks/paid":       2,
+		"payment.call.duration":     4,
+	}
+	for name, value := range want {
+		if sums[name] != value {
+			t.Errorf("%s = %d, want %d (all: %v)", name, sums[name], value, sums)
+		}
+	}
+	if len(sums) != len(want) {
+		t.Errorf("metrics = %v, want only %v", sums, want)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 4 {
+		t.Fatalf("recorded %d spans, want 4", len(ended))
+	}
+	failed := ended[3]
+	if failed.Name() != "payment.check" || len(failed.Events()) != 1 {
+		t.Fatalf("span %s has %d events, want payment.check with an error event", failed.Name(), len(failed.Events()))
+	}
+	for _, attr := range failed.Events()[0].Attributes {
+		if attr.Key == attribute.Key("exception.message") && strings.Contains(attr.Value.AsString(), "hunter2") {
+			t.Errorf("recorded error %q leaks a configured secret", attr.Value.AsString())
+		}
+	}
+}
@@ -1,68 +1,120 @@
 package types
 
+// Fields tagged `secret:"true"` are credentials; they are masked by String
+// and by the sdk config loader when printing a loaded config.
+//
+// LogoURL and MaxAmount are optional in every section. The adapter reports
+// them in its ProviderInfo: LogoURL for checkout pickers, MaxAmount as the
+// largest invoice in whole tugriks agreed with the provider, 0 for no limit.
 type (
 	QpayAdapter struct {
-		Username    string
-		Password    string
-		Endpoint    string
-		Callback    string
-		InvoiceCode string
-		MerchantID  string
+		Username    string `json:"username" yaml:"username"`
+		Password    string `json:"password" yaml:"password" secret:"true"`
+		Endpoint    string `json:"endpoint" yaml:"endpoint"`
+		Callback    string `json:"callback" yaml:"callback"`
+		InvoiceCode string `json:"invoice_code" yaml:"invoice_code"`
+		MerchantID  string `json:"merchant_id" yaml:"merchant_id"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 
 	TokipayAdapter struct {
-		Endpoint      string
-		APIKey        string
-		IMAPIKey      string
-		Authorization string
-		MerchantID    string
-		SuccessURL    string
-		FailureURL    string
-		AppSchemaIOS  string
+		Endpoint      string `json:"endpoint" yaml:"endpoint"`
+		APIKey        string `json:"api_key" yaml:"api_key" secret:"true"`
+		IMAPIKey      string `json:"im_api_key" yaml:"im_api_key" secret:"true"`
+		Authorization string `json:"authorization" yaml:"authorization" secret:"true"`
+		MerchantID    string `json:"merchant_id" yaml:"merchant_id"`
+		SuccessURL    string `json:"success_url" yaml:"success_url"`
+		FailureURL    string `json:"failure_url" yaml:"failure_url"`
+		AppSchemaIOS  string `json:"app_schema_ios" yaml:"app_schema_ios"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 
 	StorePayAdapter struct {
-		AppUserName string
-		AppPassword string
-		Username    string
-		Password    string
-		AuthUrl     string
-		BaseUrl     string
-		StoreId     string
-		CallbackUrl string
+		AppUserName string `json:"app_username" yaml:"app_username"`
+		AppPassword string `json:"app_password" yaml:"app_password" secret:"true"`
+		Username    string `json:"username" yaml:"username"`
+		Password    string `json:"password" yaml:"password" secret:"true"`
+		AuthUrl     string `json:"auth_url" yaml:"auth_url"`
+		BaseUrl     string `json:"base_url" yaml:"base_url"`
+		StoreId     string `json:"store_id" yaml:"store_id"`
+		CallbackUrl string `json:"callback_url" yaml:"callback_url"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 
 	SocialPayAdapter struct {
-		Terminal string
-		Secret   string
-		Endpoint string
+		Terminal string `json:"terminal" yaml:"terminal"`
+		Secret   string `json:"secret" yaml:"secret" secret:"true"`
+		Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 	SimpleAdapter struct {
-		UserName    string
-		Password    string
-		BaseUrl     string
-		CallbackUrl string
+		UserName    string `json:"username" yaml:"username"`
+		Password    string `json:"password" yaml:"password" secret:"true"`
+		BaseUrl     string `json:"base_url" yaml:"base_url"`
+		CallbackUrl string `json:"callback_url" yaml:"callback_url"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 	PocketAdapter struct {
-		Merchant      string
-		ClientID      string
-		ClientSecret  string
-		Environment   string
-		TerminalIDRaw int64
+		Merchant      string `json:"merchant" yaml:"merchant"`
+		ClientID      string `json:"client_id" yaml:"client_id"`
+		ClientSecret  string `json:"client_secret" yaml:"client_secret" secret:"true"`
+		Environment   string `json:"environment" yaml:"environment"`
+		TerminalIDRaw int64  `json:"terminal_id" yaml:"terminal_id"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 	MonpayAdapter struct {
-		Endpoint  string
-		Username  string
-		AccountID string
-		Callback  string
+		Endpoint  string `json:"endpoint" yaml:"endpoint"`
+		Username  string `json:"username" yaml:"username"`
+		AccountID string `json:"account_id" yaml:"account_id"`
+		Callback  string `json:"callback" yaml:"callback"`
+
+		// The deeplink fields are optional. When set, invoices are created as
+		// Monpay deeplink invoices for Username's branch instead of branch QR
+		// codes.
+		DeeplinkEndpoint string `json:"deeplink_endpoint,omitempty" yaml:"deeplink_endpoint"`
+		ClientID         string `json:"client_id,omitempty" yaml:"client_id"`
+		ClientSecret     string `json:"client_secret,omitempty" yaml:"client_secret" secret:"true"`
+		GrantType        string `json:"grant_type,omitempty" yaml:"grant_type"`
+		RedirectURL      string `json:"redirect_url,omitempty" yaml:"redirect_url"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 	GolomtAdapter struct {
-		BaseURL     string
-		Secret      string
-		BearerToken string
+		BaseURL     string `json:"base_url" yaml:"base_url"`
+		Secret      string `json:"secret" yaml:"secret" secret:"true"`
+		BearerToken string `json:"bearer_token" yaml:"bearer_token" secret:"true"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 	BalcAdapter struct {
-		Endpoint string
-		Token    string
+		Endpoint string `json:"endpoint" yaml:"endpoint"`
+		Token    string `json:"token" yaml:"token" secret:"true"`
+
+		LogoURL   string `json:"logo_url,omitempty" yaml:"logo_url"`
+		MaxAmount int64  `json:"max_amount,omitempty" yaml:"max_amount"`
 	}
 )
+
+func (c QpayAdapter) String() string      { return RedactedString(c) }
+func (c TokipayAdapter) String() string   { return RedactedString(c) }
+func (c StorePayAdapter) String() string  { return RedactedString(c) }
+func (c SocialPayAdapter) String() string { return RedactedString(c) }
+func (c SimpleAdapter) String() string    { return RedactedString(c) }
+func (c PocketAdapter) String() string    { return RedactedString(c) }
+func (c MonpayAdapter) String() string    { return RedactedString(c) }
+func (c GolomtAdapter) String() string    { return RedactedString(c) }
+func (c BalcAdapter) String() string      { return RedactedString(c) }
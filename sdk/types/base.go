@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type PaymentType string
 
 const (
@@ -14,16 +16,41 @@ const (
 	PaymentTypeBalc     PaymentType = "balc"
 )
 
+// PaymentStatus is the provider-independent state of an invoice.
+type PaymentStatus string
+
+const (
+	PaymentStatusPending       PaymentStatus = "pending"
+	PaymentStatusPaid          PaymentStatus = "paid"
+	PaymentStatusPartiallyPaid PaymentStatus = "partially_paid"
+	PaymentStatusFailed        PaymentStatus = "failed"
+	PaymentStatusExpired       PaymentStatus = "expired"
+	PaymentStatusCancelled     PaymentStatus = "cancelled"
+	PaymentStatusRefunded      PaymentStatus = "refunded"
+)
+
+// IsFinal reports whether an invoice in status s can no longer change
+// without a new operation such as a refund.
+func (s PaymentStatus) IsFinal() bool {
+	switch s {
+	case PaymentStatusPaid, PaymentStatusFailed, PaymentStatusExpired, PaymentStatusCancelled, PaymentStatusRefunded:
+		return true
+	}
+	return false
+}
+
 type (
 	InvoiceInput struct {
-		Amount      float64     // Amount
-		UID         string      // payment uid or order uid
-		Phone       string      // phone number
-		CustomerID  uint        // customer id
-		Note        string      // Note : description of the invoice
-		CallbackURL string      // CallbackURL : callback url
-		ReturnType  string      // ReturnType : return type
-		Type        PaymentType // qpay , tokipay , monpay , golomt , socialpay , storepay , pocket , simple , balc
+		Amount        Money       // Amount : exact amount, currency defaults to MNT
+		UID           string      // payment uid or order uid
+		PaymentUID    string      // PaymentUID : same as UID, either may be set
+		Phone         string      // phone number
+		CustomerID    uint        // customer id
+		Note          string      // Note : description of the invoice
+		CallbackURL   string      // CallbackURL : callback url
+		ReturnType    string      // ReturnType : return type
+		ExpireMinutes int         // ExpireMinutes : invoice lifetime for providers that support it, 0 uses the provider default
+		Type          PaymentType // qpay , tokipay , monpay , golomt , socialpay , storepay , pocket , simple , balc
 	}
 
 	InvoiceResult struct {
@@ -31,6 +58,7 @@ type (
 		BankQRCode    string     `json:"bank_qr_code"`
 		Deeplinks     []Deeplink `json:"deeplinks"`
 		IsPaid        bool       `json:"is_paid"`
+		ExpiresAt     *time.Time `json:"expires_at,omitempty"` // when the invoice or QR stops accepting payment, if known
 		Raw           any        `json:"raw"`
 	}
 
@@ -43,12 +71,45 @@ type (
 
 	CheckInvoiceInput struct {
 		UID    string      `json:"uid"`
-		Amount float64     `json:"amount"`
+		Amount Money       `json:"amount"`
 		Type   PaymentType `json:"type"`
 	}
 
 	CheckInvoiceResult struct {
-		IsPaid bool   `json:"is_paid"`
-		Msg    string `json:"msg"`
+		IsPaid         bool          `json:"is_paid"` // Status == PaymentStatusPaid
+		Status         PaymentStatus `json:"status"`
+		PaidAmount     Money         `json:"paid_amount"` // as reported by the provider, zero when it reports none
+		PaidAt         *time.Time    `json:"paid_at,omitempty"`
+		TransactionIDs []string      `json:"transaction_ids,omitempty"` // provider payment/transaction ids
+		Msg            string        `json:"msg"`                       // provider status description
+		Raw            any           `json:"raw"`
+	}
+
+	RefundInput struct {
+		UID       string      `json:"uid"`        // invoice id as used by CheckInvoice
+		PaymentID string      `json:"payment_id"` // provider payment id, optional; QPay refunds every paid payment of UID when empty
+		Amount    Money       `json:"amount"`     // amount to refund, zero refunds in full where supported; providers reject amounts they cannot refund exactly
+		Type      PaymentType `json:"type"`
+	}
+
+	// RefundResult may be returned together with an error when only part of
+	// the refund went through; RefundID then lists what was refunded.
+	RefundResult struct {
+		IsRefunded bool   `json:"is_refunded"`
+		RefundID   string `json:"refund_id"`
+		Msg        string `json:"msg"`
+		Raw        any    `json:"raw"`
+	}
+
+	CancelInput struct {
+		UID    string      `json:"uid"` // invoice id as used by CheckInvoice
+		Amount Money       `json:"amount"`
+		Type   PaymentType `json:"type"`
+	}
+
+	CancelResult struct {
+		IsCancelled bool   `json:"is_cancelled"`
+		Msg         string `json:"msg"`
+		Raw         any    `json:"raw"`
 	}
 )
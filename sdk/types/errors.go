@@ -0,0 +1,95 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Operation names an SDK operation in errors and provider metadata.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationCheck  Operation = "check"
+	OperationRefund Operation = "refund"
+	OperationCancel Operation = "cancel"
+)
+
+// Error kinds returned by the SDK. Every adapter error matches at most one of
+// them via errors.Is; unclassified provider errors match none.
+var (
+	ErrNotSupported        = errors.New("operation not supported")
+	ErrNotConfigured       = errors.New("provider not configured")
+	ErrInsufficientLimit   = errors.New("insufficient credit limit")
+	ErrInvalidInput        = errors.New("invalid input")
+	ErrProviderUnavailable = errors.New("provider unavailable")
+	ErrAuthFailed          = errors.New("provider authentication failed")
+	ErrDuplicate           = errors.New("duplicate invoice")
+	ErrIdempotencyConflict = errors.New("uid reused with different input")
+)
+
+// ErrCircuitOpen is the underlying error of an ErrProviderUnavailable failure
+// returned without calling a provider that has been failing.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// ErrOutcomeUnknown is the underlying error of a failure returned when the
+// context ended while the provider call was in flight, or when the provider
+// library panicked mid-call. The provider may still complete the call, e.g.
+// create the invoice, so check before trying again.
+var ErrOutcomeUnknown = errors.New("outcome unknown")
+
+// NotSupportedError is returned when a provider does not offer an operation.
+type NotSupportedError struct {
+	Type      PaymentType
+	Operation Operation
+}
+
+func (e *NotSupportedError) Error() string {
+	return fmt.Sprintf("%s does not support %s", e.Type, e.Operation)
+}
+
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+// ProviderError is returned by adapters for every failure of a provider
+// operation. Use errors.Is with the Err* kinds to branch on the cause and
+// errors.As to read the provider code.
+type ProviderError struct {
+	Type      PaymentType
+	Operation Operation
+	Kind      error  // one of the Err* kinds, nil when unclassified
+	Code      string // code reported by the provider, if any
+	Message   string // description when there is no underlying error
+	Err       error  // underlying provider or transport error
+}
+
+func (e *ProviderError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", e.Type, e.Operation)
+	if e.Kind != nil {
+		fmt.Fprintf(&b, ": %s", e.Kind)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, " [%s]", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %s", e.Err)
+	}
+	return b.String()
+}
+
+func (e *ProviderError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
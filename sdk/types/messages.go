@@ -0,0 +1,152 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrorCode is a stable, machine-readable identifier for an SDK error,
+// suitable for clients and message lookups. Unlike Error() it never changes
+// with provider wording.
+type ErrorCode string
+
+const (
+	ErrorCodeNotSupported        ErrorCode = "not_supported"
+	ErrorCodeNotConfigured       ErrorCode = "not_configured"
+	ErrorCodeInsufficientLimit   ErrorCode = "insufficient_limit"
+	ErrorCodeInvalidInput        ErrorCode = "invalid_input"
+	ErrorCodeProviderUnavailable ErrorCode = "provider_unavailable"
+	ErrorCodeAuthFailed          ErrorCode = "auth_failed"
+	ErrorCodeDuplicate           ErrorCode = "duplicate"
+	ErrorCodeIdempotencyConflict ErrorCode = "idempotency_conflict"
+	ErrorCodeUnknown             ErrorCode = "unknown"
+)
+
+// Language is a BCP 47 language tag used to pick user-facing messages.
+type Language string
+
+const (
+	LanguageMN Language = "mn"
+	LanguageEN Language = "en"
+)
+
+// DefaultLanguage is used when a message is missing in the requested language.
+const DefaultLanguage = LanguageMN
+
+// ParseLanguage picks the first supported language of an Accept-Language
+// header value, ignoring region subtags and quality weights, and falls back
+// to DefaultLanguage.
+func ParseLanguage(acceptLanguage string) Language {
+	for _, tag := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), ";")
+		switch primary, _, _ := strings.Cut(strings.ToLower(tag), "-"); primary {
+		case string(LanguageMN):
+			return LanguageMN
+		case string(LanguageEN):
+			return LanguageEN
+		}
+	}
+	return DefaultLanguage
+}
+
+var errorCodes = []struct {
+	kind error
+	code ErrorCode
+}{
+	{ErrNotSupported, ErrorCodeNotSupported},
+	{ErrNotConfigured, ErrorCodeNotConfigured},
+	{ErrInsufficientLimit, ErrorCodeInsufficientLimit},
+	{ErrInvalidInput, ErrorCodeInvalidInput},
+	{ErrProviderUnavailable, ErrorCodeProviderUnavailable},
+	{ErrAuthFailed, ErrorCodeAuthFailed},
+	{ErrDuplicate, ErrorCodeDuplicate},
+	{ErrIdempotencyConflict, ErrorCodeIdempotencyConflict},
+}
+
+// ErrorCodeOf returns the code of err, ErrorCodeUnknown for unclassified
+// errors and "" for nil.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	for _, c := range errorCodes {
+		if errors.Is(err, c.kind) {
+			return c.code
+		}
+	}
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return ErrorCodeInvalidInput
+	}
+	return ErrorCodeUnknown
+}
+
+func (e *NotSupportedError) ErrorCode() ErrorCode { return ErrorCodeNotSupported }
+func (e *ProviderError) ErrorCode() ErrorCode     { return ErrorCodeOf(e) }
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = map[Language]map[ErrorCode]string{
+		LanguageMN: {
+			ErrorCodeNotSupported:        "Энэ төлбөрийн хэрэгсэл уг үйлдлийг дэмждэггүй.",
+			ErrorCodeNotConfigured:       "Энэ төлбөрийн хэрэгсэл одоогоор ашиглах боломжгүй байна.",
+			ErrorCodeInsufficientLimit:   "Таны кредитийн эрх гүйлгээний дүнд хүрэхгүй байна.",
+			ErrorCodeInvalidInput:        "Төлбөрийн мэдээлэл буруу байна. Шалгаад дахин оролдоно уу.",
+			ErrorCodeProviderUnavailable: "Төлбөрийн үйлчилгээ түр ажиллахгүй байна. Хэсэг хугацааны дараа дахин оролдоно уу.",
+			ErrorCodeAuthFailed:          "Төлбөрийн үйлчилгээнд холбогдож чадсангүй. Дэлгүүрийн ажилтантай холбогдоно уу.",
+			ErrorCodeDuplicate:           "Энэ нэхэмжлэх аль хэдийн үүссэн байна.",
+			ErrorCodeIdempotencyConflict: "Энэ захиалгад өөр дүнтэй нэхэмжлэх үүссэн байна.",
+			ErrorCodeUnknown:             "Төлбөр хийхэд алдаа гарлаа. Дахин оролдоно уу.",
+		},
+		LanguageEN: {
+			ErrorCodeNotSupported:        "This payment method does not support this action.",
+			ErrorCodeNotConfigured:       "This payment method is currently unavailable.",
+			ErrorCodeInsufficientLimit:   "Your available credit is lower than the payment amount.",
+			ErrorCodeInvalidInput:        "The payment details are invalid. Please check them and try again.",
+			ErrorCodeProviderUnavailable: "The payment service is temporarily unavailable. Please try again later.",
+			ErrorCodeAuthFailed:          "We could not connect to the payment service. Please contact the store.",
+			ErrorCodeDuplicate:           "This invoice has already been created.",
+			ErrorCodeIdempotencyConflict: "An invoice with a different amount already exists for this order.",
+			ErrorCodeUnknown:             "Something went wrong with the payment. Please try again.",
+		},
+	}
+)
+
+// RegisterMessages adds or overrides user-facing messages for lang, e.g. to
+// add a language or reword a message for a storefront.
+func RegisterMessages(lang Language, messages map[ErrorCode]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if catalog[lang] == nil {
+		catalog[lang] = make(map[ErrorCode]string, len(messages))
+	}
+	for code, message := range messages {
+		catalog[lang][code] = message
+	}
+}
+
+// Message returns the user-facing message for code in lang, falling back to
+// DefaultLanguage and then to the message for ErrorCodeUnknown.
+func Message(code ErrorCode, lang Language) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	for _, l := range []Language{lang, DefaultLanguage} {
+		if message, ok := catalog[l][code]; ok {
+			return message
+		}
+	}
+	if message, ok := catalog[lang][ErrorCodeUnknown]; ok {
+		return message
+	}
+	return catalog[DefaultLanguage][ErrorCodeUnknown]
+}
+
+// UserMessage returns a message for err that is safe to show to payers. It
+// never includes provider text; log err itself for diagnostics.
+func UserMessage(err error, lang Language) string {
+	if err == nil {
+		return ""
+	}
+	return Message(ErrorCodeOf(err), lang)
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var allErrorCodes = []ErrorCode{
+	ErrorCodeNotSupported,
+	ErrorCodeNotConfigured,
+	ErrorCodeInsufficientLimit,
+	ErrorCodeInvalidInput,
+	ErrorCodeProviderUnavailable,
+	ErrorCodeAuthFailed,
+	ErrorCodeDuplicate,
+	ErrorCodeIdempotencyConflict,
+	ErrorCodeUnknown,
+}
+
+func TestMessagesComplete(t *testing.T) {
+	if len(allErrorCodes) != len(errorCodes)+1 {
+		t.Fatalf("allErrorCodes lists %d codes, want every kind's code and ErrorCodeUnknown", len(allErrorCodes))
+	}
+	for _, code := range allErrorCodes {
+		mn, mnOK := catalog[LanguageMN][code]
+		en, enOK := catalog[LanguageEN][code]
+		if !mnOK || !enOK || mn == "" || en == "" {
+			t.Errorf("%s: mn = %q, en = %q, want both", code, mn, en)
+		}
+		if mn == en {
+			t.Errorf("%s: mn and en are the same: %q", code, mn)
+		}
+	}
+	for _, lang := range []Language{LanguageMN, LanguageEN} {
+		if len(catalog[lang]) != len(allErrorCodes) {
+			t.Errorf("%s has %d messages, want %d", lang, len(catalog[lang]), len(allErrorCodes))
+		}
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	const lang Language = "test-override"
+	original := Message(ErrorCodeDuplicate, LanguageEN)
+	t.Cleanup(func() {
+		RegisterMessages(LanguageEN, map[ErrorCode]string{ErrorCodeDuplicate: original})
+		catalogMu.Lock()
+		delete(catalog, lang)
+		catalogMu.Unlock()
+	})
+
+	RegisterMessages(LanguageEN, map[ErrorCode]string{ErrorCodeDuplicate: "Already paid for."})
+	RegisterMessages(lang, map[ErrorCode]string{ErrorCodeAuthFailed: "auth", ErrorCodeUnknown: "unknown"})
+
+	tests := []struct {
+		code ErrorCode
+		lang Language
+		want string
+	}{
+		{code: ErrorCodeDuplicate, lang: LanguageEN, want: "Already paid for."},
+		{code: ErrorCodeAuthFailed, lang: LanguageEN, want: catalog[LanguageEN][ErrorCodeAuthFailed]},
+		{code: ErrorCodeAuthFailed, lang: lang, want: "auth"},
+		// Missing in lang: the default language has it.
+		{code: ErrorCodeDuplicate, lang: lang, want: catalog[DefaultLanguage][ErrorCodeDuplicate]},
+		// Unknown code: lang's own unknown message.
+		{code: "no_such_code", lang: lang, want: "unknown"},
+		{code: "no_such_code", lang: "fr", want: catalog[DefaultLanguage][ErrorCodeUnknown]},
+	}
+	for _, tt := range tests {
+		if got := Message(tt.code, tt.lang); got != tt.want {
+			t.Errorf("Message(%s, %s) = %q, want %q", tt.code, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestUserMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: ""},
+		{err: &ProviderError{Type: PaymentTypeQPay, Operation: OperationCreate, Kind: ErrProviderUnavailable, Message: "upstream 502: secret body"}, want: catalog[LanguageEN][ErrorCodeProviderUnavailable]},
+		{err: fmt.Errorf("create: %w", ErrIdempotencyConflict), want: catalog[LanguageEN][ErrorCodeIdempotencyConflict]},
+		{err: &ValidationError{}, want: catalog[LanguageEN][ErrorCodeInvalidInput]},
+		{err: errors.New("boom"), want: catalog[LanguageEN][ErrorCodeUnknown]},
+	}
+	for _, tt := range tests {
+		if got := UserMessage(tt.err, LanguageEN); got != tt.want {
+			t.Errorf("UserMessage(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Language
+	}{
+		{header: "", want: DefaultLanguage},
+		{header: "en", want: LanguageEN},
+		{header: "en-US,en;q=0.9", want: LanguageEN},
+		{header: "fr-FR, EN;q=0.8, mn;q=0.5", want: LanguageEN},
+		{header: "mn-MN", want: LanguageMN},
+		{header: "de, fr", want: DefaultLanguage},
+	}
+	for _, tt := range tests {
+		if got := ParseLanguage(tt.header); got != tt.want {
+			t.Errorf("ParseLanguage(%q) = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CurrencyMNT is the currency assumed when Money.Currency is empty.
+const CurrencyMNT = "MNT"
+
+// currencyExponents holds the number of minor-unit digits per ISO 4217 code;
+// unknown currencies use 2.
+var currencyExponents = map[string]int{
+	"MNT": 2,
+	"USD": 2,
+	"EUR": 2,
+	"CNY": 2,
+	"KRW": 0,
+	"JPY": 0,
+}
+
+// Money is an exact amount in minor units of Currency (1 MNT = 100 möngö).
+type Money struct {
+	Minor    int64  `json:"minor"`
+	Currency string `json:"currency"` // ISO 4217 code, empty means MNT
+}
+
+// NewMoney returns minor units of currency.
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: strings.ToUpper(currency)}
+}
+
+// MNT returns a whole tugrik amount.
+func MNT(tugrik int64) Money {
+	return Money{Minor: tugrik * 100, Currency: CurrencyMNT}
+}
+
+// ParseMoney parses a decimal string such as "15000" or "15000.50" exactly.
+// Digits beyond the currency's minor unit are rejected unless they are zero.
+func ParseMoney(value, currency string) (Money, error) {
+	m := Money{Currency: strings.ToUpper(currency)}
+	value = strings.TrimSpace(value)
+
+	sign := int64(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign, value = -1, value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	exp := m.exponent()
+	if trimmed := strings.TrimRight(fraction, "0"); len(trimmed) > exp {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places for %s", value, exp, m.CurrencyCode())
+	}
+	fraction = (fraction + strings.Repeat("0", exp))[:exp]
+
+	digits := whole + fraction
+	if digits == "" {
+		digits = "0"
+	}
+	if strings.ContainsAny(digits, "+-") {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	m.Minor = sign * minor
+	return m, nil
+}
+
+// MoneyFromFloat rounds a provider-reported float to the nearest minor unit.
+// Use it only for amounts read back from providers, never for caller input.
+func MoneyFromFloat(value float64, currency string) Money {
+	m := Money{Currency: strings.ToUpper(currency)}
+	m.Minor = int64(math.Round(value * math.Pow10(m.exponent())))
+	return m
+}
+
+// CurrencyCode returns Currency, or MNT when it is empty.
+func (m Money) CurrencyCode() string {
+	if m.Currency == "" {
+		return CurrencyMNT
+	}
+	return strings.ToUpper(m.Currency)
+}
+
+func (m Money) exponent() int {
+	if exp, ok := currencyExponents[m.CurrencyCode()]; ok {
+		return exp
+	}
+	return 2
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+
+// SameCurrency reports whether m and other are in the same currency.
+func (m Money) SameCurrency(other Money) bool {
+	return m.CurrencyCode() == other.CurrencyCode()
+}
+
+// Cmp compares amounts of the same currency, returning -1, 0 or +1. Callers
+// must check SameCurrency first.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.Minor < other.Minor:
+		return -1
+	case m.Minor > other.Minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Add sums amounts of the same currency.
+func (m Money) Add(other Money) Money {
+	return Money{Minor: m.Minor + other.Minor, Currency: m.CurrencyCode()}
+}
+
+// WholeUnits returns the amount in major units, failing when it has a
+// fractional part that a provider accepting integers would drop.
+func (m Money) WholeUnits() (int64, error) {
+	scale := int64(math.Pow10(m.exponent()))
+	if m.Minor%scale != 0 {
+		return 0, fmt.Errorf("amount %s has a fractional part", m)
+	}
+	return m.Minor / scale, nil
+}
+
+// Float64 returns the amount in major units for provider APIs that take a
+// float. Amounts within the float64 integer range convert exactly to the
+// two-decimal strings those APIs send.
+func (m Money) Float64() float64 {
+	return float64(m.Minor) / math.Pow10(m.exponent())
+}
+
+// Decimal formats the amount in major units, e.g. "15000.50".
+func (m Money) Decimal() string {
+	exp := m.exponent()
+	if exp == 0 {
+		return strconv.FormatInt(m.Minor, 10)
+	}
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	scale := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/scale, exp, minor%scale)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.CurrencyCode()
+}
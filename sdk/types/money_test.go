@@ -0,0 +1,148 @@
+package types
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{value: "15000", want: Money{Minor: 1500000}},
+		{value: "15000.50", currency: "MNT", want: Money{Minor: 1500050, Currency: "MNT"}},
+		{value: "15000.5", currency: "mnt", want: Money{Minor: 1500050, Currency: "MNT"}},
+		{value: " 0.01 ", currency: "MNT", want: Money{Minor: 1, Currency: "MNT"}},
+		{value: ".5", currency: "MNT", want: Money{Minor: 50, Currency: "MNT"}},
+		{value: "7.", currency: "MNT", want: Money{Minor: 700, Currency: "MNT"}},
+		{value: "1.000", currency: "MNT", want: Money{Minor: 100, Currency: "MNT"}},
+		{value: "+12", currency: "MNT", want: Money{Minor: 1200, Currency: "MNT"}},
+		{value: "-12.34", currency: "MNT", want: Money{Minor: -1234, Currency: "MNT"}},
+		{value: "100", currency: "KRW", want: Money{Minor: 100, Currency: "KRW"}},
+		{value: "100.0", currency: "KRW", want: Money{Minor: 100, Currency: "KRW"}},
+		{value: "9.99", currency: "XYZ", want: Money{Minor: 999, Currency: "XYZ"}},
+
+		{value: "1.001", currency: "MNT", wantErr: true},
+		{value: "100.5", currency: "KRW", wantErr: true},
+		{value: "", wantErr: true},
+		{value: ".", wantErr: true},
+		{value: "-", wantErr: true},
+		{value: "--5", wantErr: true},
+		{value: "+-5", wantErr: true},
+		{value: "1.-5", wantErr: true},
+		{value: "1.2.3", wantErr: true},
+		{value: "1e3", wantErr: true},
+		{value: "1,000", wantErr: true},
+		{value: "1 000", wantErr: true},
+		{value: "1_000", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "92233720368547758.08", wantErr: true}, // overflows int64 minor units
+	}
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney(%q) = %+v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseMoney(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: MNT(15000), want: "15000.00 MNT"},
+		{money: NewMoney(1500050, "mnt"), want: "15000.50 MNT"},
+		{money: Money{Minor: 5}, want: "0.05 MNT"},
+		{money: Money{Minor: -5}, want: "-0.05 MNT"},
+		{money: Money{Minor: -1234}, want: "-12.34 MNT"},
+		{money: NewMoney(100, "KRW"), want: "100 KRW"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.money, got, tt.want)
+		}
+		// Decimal output parses back to the same amount.
+		back, err := ParseMoney(tt.money.Decimal(), tt.money.Currency)
+		if err != nil || back.Minor != tt.money.Minor {
+			t.Errorf("ParseMoney(%q) = %+v, %v, want minor %d", tt.money.Decimal(), back, err, tt.money.Minor)
+		}
+	}
+}
+
+func TestMoneyWholeUnits(t *testing.T) {
+	tests := []struct {
+		money   Money
+		want    int64
+		wantErr bool
+	}{
+		{money: MNT(15000), want: 15000},
+		{money: NewMoney(1500050, "MNT"), wantErr: true},
+		{money: NewMoney(1, "MNT"), wantErr: true},
+		{money: NewMoney(250, "KRW"), want: 250},
+	}
+	for _, tt := range tests {
+		got, err := tt.money.WholeUnits()
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%+v.WholeUnits() = %d, %v, want %d, error %v", tt.money, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		want     int64
+	}{
+		{value: 15000, currency: "MNT", want: 1500000},
+		{value: 0.1 + 0.2, currency: "MNT", want: 30},
+		{value: 1.005, currency: "MNT", want: 100}, // 1.005 is stored just below the half
+		{value: 19.99, currency: "MNT", want: 1999},
+		{value: 250, currency: "KRW", want: 250},
+	}
+	for _, tt := range tests {
+		if got := MoneyFromFloat(tt.value, tt.currency); got.Minor != tt.want {
+			t.Errorf("MoneyFromFloat(%v, %s) = %d, want %d", tt.value, tt.currency, got.Minor, tt.want)
+		}
+	}
+}
+
+func TestMoneyCompare(t *testing.T) {
+	tests := []struct {
+		a, b    Money
+		same    bool
+		cmp     int
+		wantSum Money
+	}{
+		{a: MNT(10), b: NewMoney(1000, ""), same: true, cmp: 0, wantSum: Money{Minor: 2000, Currency: "MNT"}},
+		{a: MNT(1), b: MNT(2), same: true, cmp: -1, wantSum: Money{Minor: 300, Currency: "MNT"}},
+		{a: NewMoney(500, "usd"), b: NewMoney(100, "USD"), same: true, cmp: 1, wantSum: Money{Minor: 600, Currency: "USD"}},
+		{a: MNT(1), b: NewMoney(100, "USD"), same: false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.SameCurrency(tt.b); got != tt.same {
+			t.Errorf("%+v.SameCurrency(%+v) = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+		if !tt.same {
+			continue
+		}
+		if got := tt.a.Cmp(tt.b); got != tt.cmp {
+			t.Errorf("%+v.Cmp(%+v) = %d, want %d", tt.a, tt.b, got, tt.cmp)
+		}
+		if got := tt.a.Add(tt.b); got != tt.wantSum {
+			t.Errorf("%+v.Add(%+v) = %+v, want %+v", tt.a, tt.b, got, tt.wantSum)
+		}
+	}
+}
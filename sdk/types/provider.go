@@ -0,0 +1,60 @@
+package types
+
+import "context"
+
+// PaymentProvider is implemented by every adapter that can be registered with
+// the SDK under a PaymentType. Third-party providers implement the same
+// interface to plug into the SDK or replace a built-in adapter.
+type PaymentProvider interface {
+	CreateInvoiceContext(ctx context.Context, input InvoiceInput) (*InvoiceResult, error)
+	CheckInvoiceContext(ctx context.Context, input CheckInvoiceInput) (*CheckInvoiceResult, error)
+}
+
+// InvoiceRefunder is implemented by providers that can refund a paid invoice.
+type InvoiceRefunder interface {
+	RefundInvoiceContext(ctx context.Context, input RefundInput) (*RefundResult, error)
+}
+
+// InvoiceCanceler is implemented by providers that can cancel an unpaid invoice.
+type InvoiceCanceler interface {
+	CancelInvoiceContext(ctx context.Context, input CancelInput) (*CancelResult, error)
+}
+
+// ProviderInfo describes a payment method for checkout pickers.
+type ProviderInfo struct {
+	Type         PaymentType `json:"type" yaml:"type"`
+	DisplayName  string      `json:"display_name" yaml:"display_name"`
+	LogoURL      string      `json:"logo_url,omitempty" yaml:"logo_url"`
+	Operations   []Operation `json:"operations" yaml:"operations"`
+	MinAmount    Money       `json:"min_amount" yaml:"min_amount"`         // smallest accepted amount
+	MaxAmount    Money       `json:"max_amount" yaml:"max_amount"`         // zero means no limit known
+	WholeAmount  bool        `json:"whole_amount" yaml:"whole_amount"`     // only whole tugriks are accepted
+	MaxUIDLength int         `json:"max_uid_length" yaml:"max_uid_length"` // zero means DefaultMaxUIDLength
+
+	RequiresPhone       bool `json:"requires_phone" yaml:"requires_phone"`
+	RequiresCustomerID  bool `json:"requires_customer_id" yaml:"requires_customer_id"`
+	RequiresCallbackURL bool `json:"requires_callback_url" yaml:"requires_callback_url"`
+	// CheckRequiresAmount reports that Check compares the payment against
+	// CheckInvoiceInput.Amount, which must then be set.
+	CheckRequiresAmount bool `json:"check_requires_amount" yaml:"check_requires_amount"`
+
+	// DedupesCreate reports that the provider never opens a second invoice
+	// for a repeated InvoiceInput.UID, so Create is safe to retry after an
+	// ambiguous failure such as a timeout.
+	DedupesCreate bool `json:"dedupes_create" yaml:"dedupes_create"`
+
+	Available bool `json:"available" yaml:"available"` // circuit breaker lets calls through
+}
+
+// ProviderDescriber is implemented by providers that describe themselves in
+// ProviderInfo. Available is filled in by the SDK, and so are Operations
+// unless Info lists them, e.g. to leave out a Check it cannot perform.
+type ProviderDescriber interface {
+	Info() ProviderInfo
+}
+
+// InvoiceValidator is implemented by providers with input rules that
+// ProviderInfo cannot express. ValidateInvoice must not call the network.
+type InvoiceValidator interface {
+	ValidateInvoice(input InvoiceInput) error
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Redacted replaces secret values in printed configs.
+const Redacted = "***"
+
+// IsSecretField reports whether a struct field holds a credential.
+func IsSecretField(field reflect.StructField) bool {
+	return field.Tag.Get("secret") == "true"
+}
+
+// RedactedString formats a struct like %+v, masking non-empty fields tagged
+// `secret:"true"`. Empty secrets print as "" so missing credentials stay visible.
+func RedactedString(v any) string {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Sprintf("%v", v)
+	}
+
+	rt := rv.Type()
+	parts := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := rv.Field(i)
+		switch {
+		case IsSecretField(field) && !value.IsZero():
+			parts = append(parts, field.Name+":"+Redacted)
+		case value.Kind() == reflect.String:
+			parts = append(parts, fmt.Sprintf("%s:%q", field.Name, value.String()))
+		default:
+			parts = append(parts, fmt.Sprintf("%s:%v", field.Name, value.Interface()))
+		}
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// secretKeyParts mark payload keys holding credentials or card data, matched
+// against the lowercased key without separators.
+var secretKeyParts = []string{"password", "passwd", "secret", "token", "apikey", "authorization", "credential", "privatekey", "card", "cvv", "cvc"}
+
+// IsSecretKey reports whether a payload key such as "access_token" or
+// "cardNumber" names a credential or card data.
+func IsSecretKey(key string) bool {
+	key = strings.ToLower(strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(key))
+	switch key {
+	case "pan", "pin", "key":
+		return true
+	}
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretValues collects the non-empty fields tagged `secret:"true"` of v and
+// of the structs nested in it, e.g. every credential of an sdk Input.
+func SecretValues(v any) []string {
+	var values []string
+	collectSecrets(reflect.ValueOf(v), &values)
+	return values
+}
+
+func collectSecrets(rv reflect.Value, values *[]string) {
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := rv.Field(i)
+		switch {
+		case IsSecretField(field) && value.Kind() == reflect.String && value.String() != "":
+			*values = append(*values, value.String())
+		case value.Kind() == reflect.Struct:
+			collectSecrets(value, values)
+		}
+	}
+}
+
+// Redactor masks credentials in values about to be logged: payload keys
+// matching IsSecretKey and every occurrence of the known secret values. A nil
+// Redactor only masks by key.
+type Redactor struct {
+	replacer *strings.Replacer
+}
+
+// NewRedactor returns a Redactor for the given secrets, typically
+// SecretValues of the provider configs.
+func NewRedactor(secrets ...string) *Redactor {
+	var pairs []string
+	for _, secret := range secrets {
+		// Very short values would mask unrelated text.
+		if len(secret) >= 4 {
+			pairs = append(pairs, secret, Redacted)
+		}
+	}
+	if len(pairs) == 0 {
+		return &Redactor{}
+	}
+	return &Redactor{replacer: strings.NewReplacer(pairs...)}
+}
+
+// String masks the known secret values in s, e.g. in a provider error body.
+func (r *Redactor) String(s string) string {
+	if r == nil || r.replacer == nil {
+		return s
+	}
+	return r.replacer.Replace(s)
+}
+
+// Value returns a JSON-shaped copy of v with secret keys and values masked.
+// Strings holding a JSON document, such as raw response bodies, are decoded
+// and masked as well.
+func (r *Redactor) Value(v any) any {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Redacted
+	}
+	decoded, ok := decodeJSON(data)
+	if !ok {
+		return Redacted
+	}
+	return r.redact(decoded)
+}
+
+func (r *Redactor) redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if IsSecretKey(key) && value != nil && value != "" {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = r.redact(value)
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = r.redact(value)
+		}
+		return v
+	case string:
+		if trimmed := strings.TrimSpace(v); strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			if decoded, ok := decodeJSON([]byte(trimmed)); ok {
+				return r.redact(decoded)
+			}
+		}
+		return r.String(v)
+	default:
+		return v
+	}
+}
+
+func decodeJSON(data []byte) (any, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "access_token", want: true},
+		{key: "refresh-token", want: true},
+		{key: "cardNumber", want: true},
+		{key: "card_number", want: true},
+		{key: "key", want: true},
+		{key: "PAN", want: true},
+		{key: "Authorization", want: true},
+		{key: "client_secret", want: true},
+		{key: "api_key", want: true},
+		{key: "cvv", want: true},
+		{key: "invoice_id", want: false},
+		{key: "amount", want: false},
+		{key: "keyword", want: false},
+		{key: "description", want: false},
+	}
+	for _, tt := range tests {
+		if got := IsSecretKey(tt.key); got != tt.want {
+			t.Errorf("IsSecretKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestRedactorValue(t *testing.T) {
+	r := NewRedactor("merchant-password", "abc")
+	tests := []struct {
+		name string
+		in   any
+		want any
+	}{
+		{
+			name: "nested keys",
+			in: map[string]any{
+				"invoice_id": "inv-1",
+				"auth":       map[string]any{"access_token": "tok-123", "expires_in": 3600},
+				"payments":   []any{map[string]any{"card_number": "4000-00XX-XXXX-0000", "amount": "1000"}},
+			},
+			want: map[string]any{
+				"invoice_id": "inv-1",
+				"auth":       map[string]any{"access_token": Redacted, "expires_in": "3600"},
+				"payments":   []any{map[string]any{"card_number": Redacted, "amount": "1000"}},
+			},
+		},
+		{
+			name: "JSON in a string",
+			in:   map[string]any{"body": `{"token": "tok-123", "status": "PAID"}`},
+			want: map[string]any{"body": map[string]any{"token": Redacted, "status": "PAID"}},
+		},
+		{
+			name: "known secret value",
+			in:   map[string]any{"error": "login merchant-password rejected"},
+			want: map[string]any{"error": "login *** rejected"},
+		},
+		{
+			// "abc" is too short to mask without hiding unrelated text.
+			name: "short secret",
+			in:   map[string]any{"description": "abc order"},
+			want: map[string]any{"description": "abc order"},
+		},
+		{
+			name: "empty secret key",
+			in:   map[string]any{"token": ""},
+			want: map[string]any{"token": ""},
+		},
+		{
+			name: "struct",
+			in:   struct{ Password, User string }{Password: "p", User: "merchant"},
+			want: map[string]any{"Password": Redacted, "User": "merchant"},
+		},
+		{name: "nil", in: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := normalizeNumbers(r.Value(tt.in))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Value(%v) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// normalizeNumbers turns the json.Number values Value decodes into strings.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	case interface{ String() string }:
+		return v.String()
+	}
+	return v
+}
+
+func TestRedactedString(t *testing.T) {
+	got := RedactedString(QpayAdapter{Username: "merchant", Password: "hunter2"})
+	want := `{Username:"merchant" Password:*** Endpoint:"" Callback:"" InvoiceCode:"" MerchantID:"" LogoURL:"" MaxAmount:0}`
+	if got != want {
+		t.Fatalf("RedactedString = %s, want %s", got, want)
+	}
+}
+
+func TestSecretValues(t *testing.T) {
+	config := struct {
+		Qpay    QpayAdapter
+		TokiPay TokipayAdapter
+	}{
+		Qpay:    QpayAdapter{Username: "merchant", Password: "hunter2"},
+		TokiPay: TokipayAdapter{APIKey: "toki-key", MerchantID: "m-1"},
+	}
+	got := SecretValues(config)
+	if want := []string{"hunter2", "toki-key"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("SecretValues = %v, want %v", got, want)
+	}
+}
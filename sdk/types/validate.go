@@ -0,0 +1,307 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FieldError describes a single missing or malformed field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError lists every invalid field found by a Validate call.
+type ValidationError struct {
+	Fields []*FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		msgs[i] = field.Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes each FieldError to errors.As.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, field := range e.Fields {
+		errs[i] = field
+	}
+	return errs
+}
+
+// Validator accumulates field errors; the zero value is ready to use.
+type Validator struct {
+	fields []*FieldError
+}
+
+// Add records a field error.
+func (v *Validator) Add(field, message string) {
+	v.fields = append(v.fields, &FieldError{Field: field, Message: message})
+}
+
+// Merge records the field errors of err under prefix. Errors that are not a
+// *ValidationError are recorded as a single field error for prefix.
+func (v *Validator) Merge(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		v.Add(prefix, err.Error())
+		return
+	}
+	for _, field := range verr.Fields {
+		v.Add(prefix+"."+field.Field, field.Message)
+	}
+}
+
+func (v *Validator) Required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, "is required")
+	}
+}
+
+// URL requires value to be an absolute http or https URL.
+func (v *Validator) URL(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.Add(field, "is required")
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.Add(field, "must be an absolute http(s) URL")
+	}
+}
+
+// display checks the optional LogoURL and MaxAmount of an adapter config.
+func (v *Validator) display(logoURL string, maxAmount int64) {
+	if logoURL != "" {
+		v.URL("LogoURL", logoURL)
+	}
+	if maxAmount < 0 {
+		v.Add("MaxAmount", "must not be negative")
+	}
+}
+
+// Err returns a *ValidationError, or nil when no field failed.
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
+
+func (c QpayAdapter) Validate() error {
+	var v Validator
+	v.Required("Username", c.Username)
+	v.Required("Password", c.Password)
+	v.URL("Endpoint", c.Endpoint)
+	v.URL("Callback", c.Callback)
+	v.Required("InvoiceCode", c.InvoiceCode)
+	v.Required("MerchantID", c.MerchantID)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c TokipayAdapter) Validate() error {
+	var v Validator
+	v.URL("Endpoint", c.Endpoint)
+	v.Required("APIKey", c.APIKey)
+	v.Required("IMAPIKey", c.IMAPIKey)
+	v.Required("Authorization", c.Authorization)
+	v.Required("MerchantID", c.MerchantID)
+	v.URL("SuccessURL", c.SuccessURL)
+	v.URL("FailureURL", c.FailureURL)
+	v.Required("AppSchemaIOS", c.AppSchemaIOS)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c StorePayAdapter) Validate() error {
+	var v Validator
+	v.Required("AppUserName", c.AppUserName)
+	v.Required("AppPassword", c.AppPassword)
+	v.Required("Username", c.Username)
+	v.Required("Password", c.Password)
+	v.URL("AuthUrl", c.AuthUrl)
+	v.URL("BaseUrl", c.BaseUrl)
+	v.Required("StoreId", c.StoreId)
+	v.URL("CallbackUrl", c.CallbackUrl)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c SocialPayAdapter) Validate() error {
+	var v Validator
+	v.Required("Terminal", c.Terminal)
+	v.Required("Secret", c.Secret)
+	v.URL("Endpoint", c.Endpoint)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c SimpleAdapter) Validate() error {
+	var v Validator
+	v.Required("UserName", c.UserName)
+	v.Required("Password", c.Password)
+	v.URL("BaseUrl", c.BaseUrl)
+	v.URL("CallbackUrl", c.CallbackUrl)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c PocketAdapter) Validate() error {
+	var v Validator
+	v.Required("Merchant", c.Merchant)
+	v.Required("ClientID", c.ClientID)
+	v.Required("ClientSecret", c.ClientSecret)
+	// pocket-go falls back to production for an empty environment.
+	if c.Environment != "" && c.Environment != "production" && c.Environment != "sandbox" {
+		v.Add("Environment", `must be "production" or "sandbox"`)
+	}
+	if c.TerminalIDRaw <= 0 {
+		v.Add("TerminalIDRaw", "must be a positive terminal id")
+	}
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c MonpayAdapter) Validate() error {
+	var v Validator
+	v.URL("Endpoint", c.Endpoint)
+	v.Required("Username", c.Username)
+	v.Required("AccountID", c.AccountID)
+	v.URL("Callback", c.Callback)
+	if c.Deeplink() || c.ClientID != "" || c.ClientSecret != "" || c.GrantType != "" || c.RedirectURL != "" {
+		v.URL("DeeplinkEndpoint", c.DeeplinkEndpoint)
+		v.Required("ClientID", c.ClientID)
+		v.Required("ClientSecret", c.ClientSecret)
+		v.Required("GrantType", c.GrantType)
+		v.URL("RedirectURL", c.RedirectURL)
+	}
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+// Deeplink reports whether invoices are created as Monpay deeplink invoices.
+func (c MonpayAdapter) Deeplink() bool {
+	return c.DeeplinkEndpoint != ""
+}
+
+func (c GolomtAdapter) Validate() error {
+	var v Validator
+	v.URL("BaseURL", c.BaseURL)
+	v.Required("Secret", c.Secret)
+	v.Required("BearerToken", c.BearerToken)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+func (c BalcAdapter) Validate() error {
+	var v Validator
+	v.URL("Endpoint", c.Endpoint)
+	v.Required("Token", c.Token)
+	v.display(c.LogoURL, c.MaxAmount)
+	return v.Err()
+}
+
+// DefaultMaxUIDLength bounds InvoiceInput.UID for providers that do not set
+// ProviderInfo.MaxUIDLength.
+const DefaultMaxUIDLength = 64
+
+// ResolveUID returns input with UID taken from PaymentUID when only the
+// latter is set. Setting both to different values is a *ValidationError.
+func (input InvoiceInput) ResolveUID() (InvoiceInput, error) {
+	switch {
+	case input.UID == "":
+		input.UID = input.PaymentUID
+	case input.PaymentUID != "" && input.PaymentUID != input.UID:
+		var v Validator
+		v.Add("PaymentUID", "must match UID when both are set")
+		return input, v.Err()
+	}
+	return input, nil
+}
+
+// Validate checks input against the requirements in info without calling
+// the provider and returns a *ValidationError listing every invalid field.
+func (input InvoiceInput) Validate(info ProviderInfo) error {
+	var v Validator
+
+	switch amount := input.Amount; {
+	case amount.CurrencyCode() != CurrencyMNT:
+		v.Add("Amount", "only "+CurrencyMNT+" amounts are supported")
+	case !amount.IsPositive():
+		v.Add("Amount", "must be positive")
+	case info.WholeAmount && amount.Minor%100 != 0:
+		v.Add("Amount", "must be a whole "+CurrencyMNT+" amount")
+	case !info.MinAmount.IsZero() && amount.Cmp(info.MinAmount) < 0:
+		v.Add("Amount", "must be at least "+info.MinAmount.String())
+	case !info.MaxAmount.IsZero() && amount.Cmp(info.MaxAmount) > 0:
+		v.Add("Amount", "must be at most "+info.MaxAmount.String())
+	}
+
+	maxUID := info.MaxUIDLength
+	if maxUID <= 0 {
+		maxUID = DefaultMaxUIDLength
+	}
+	switch {
+	case input.UID == "":
+		v.Add("UID", "is required")
+	case len(input.UID) > maxUID:
+		v.Add("UID", fmt.Sprintf("must be at most %d characters", maxUID))
+	case strings.IndexFunc(input.UID, func(r rune) bool { return !isUIDRune(r) }) >= 0:
+		v.Add("UID", "may only contain letters, digits, '-', '_' and '.'")
+	}
+
+	if info.RequiresPhone {
+		switch {
+		case input.Phone == "":
+			v.Add("Phone", "is required")
+		case !IsMongolianPhone(input.Phone):
+			v.Add("Phone", "must be an 8-digit Mongolian number, optionally prefixed with +976")
+		}
+	}
+	if info.RequiresCustomerID && input.CustomerID == 0 {
+		v.Add("CustomerID", "is required")
+	}
+	if info.RequiresCallbackURL {
+		v.URL("CallbackURL", input.CallbackURL)
+	}
+	if input.ExpireMinutes < 0 {
+		v.Add("ExpireMinutes", "must not be negative")
+	}
+	return v.Err()
+}
+
+func isUIDRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.'
+}
+
+// IsMongolianPhone reports whether phone is an 8-digit Mongolian number,
+// optionally written with the +976 or 976 country code.
+func IsMongolianPhone(phone string) bool {
+	phone = strings.TrimPrefix(phone, "+")
+	if len(phone) == 11 && strings.HasPrefix(phone, "976") {
+		phone = phone[3:]
+	}
+	if len(phone) != 8 || phone[0] == '0' {
+		return false
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package types
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// invalidFields returns the field names listed by a *ValidationError.
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	fields := make([]string, len(verr.Fields))
+	for i, field := range verr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func TestInvoiceInputValidate(t *testing.T) {
+	valid := InvoiceInput{Amount: MNT(1000), UID: "order-1.a_b", Phone: "99119911", CustomerID: 7, CallbackURL: "https://shop.mn/cb"}
+	strict := ProviderInfo{
+		MinAmount:           MNT(100),
+		MaxAmount:           MNT(10000),
+		WholeAmount:         true,
+		MaxUIDLength:        16,
+		RequiresPhone:       true,
+		RequiresCustomerID:  true,
+		RequiresCallbackURL: true,
+	}
+
+	tests := []struct {
+		name   string
+		info   ProviderInfo
+		modify func(*InvoiceInput)
+		want   []string
+	}{
+		{name: "valid", info: strict},
+		{name: "valid without requirements", modify: func(in *InvoiceInput) { *in = InvoiceInput{Amount: NewMoney(150, CurrencyMNT), UID: "x"} }},
+		{name: "other currency", info: strict, modify: func(in *InvoiceInput) { in.Amount = NewMoney(1000, "USD") }, want: []string{"Amount"}},
+		{name: "zero amount", info: strict, modify: func(in *InvoiceInput) { in.Amount = Money{} }, want: []string{"Amount"}},
+		{name: "negative amount", modify: func(in *InvoiceInput) { in.Amount = MNT(-5) }, want: []string{"Amount"}},
+		{name: "fractional amount", info: strict, modify: func(in *InvoiceInput) { in.Amount = NewMoney(100050, CurrencyMNT) }, want: []string{"Amount"}},
+		{name: "below minimum", info: strict, modify: func(in *InvoiceInput) { in.Amount = MNT(99) }, want: []string{"Amount"}},
+		{name: "above maximum", info: strict, modify: func(in *InvoiceInput) { in.Amount = MNT(10001) }, want: []string{"Amount"}},
+		{name: "at maximum", info: strict, modify: func(in *InvoiceInput) { in.Amount = MNT(10000) }},
+		{name: "missing UID", info: strict, modify: func(in *InvoiceInput) { in.UID = "" }, want: []string{"UID"}},
+		{name: "long UID", info: strict, modify: func(in *InvoiceInput) { in.UID = strings.Repeat("a", 17) }, want: []string{"UID"}},
+		{name: "default UID length", modify: func(in *InvoiceInput) { in.UID = strings.Repeat("a", DefaultMaxUIDLength+1) }, want: []string{"UID"}},
+		{name: "UID characters", info: strict, modify: func(in *InvoiceInput) { in.UID = "order 1" }, want: []string{"UID"}},
+		{name: "missing phone", info: strict, modify: func(in *InvoiceInput) { in.Phone = "" }, want: []string{"Phone"}},
+		{name: "bad phone", info: strict, modify: func(in *InvoiceInput) { in.Phone = "12345" }, want: []string{"Phone"}},
+		{name: "missing customer", info: strict, modify: func(in *InvoiceInput) { in.CustomerID = 0 }, want: []string{"CustomerID"}},
+		{name: "relative callback", info: strict, modify: func(in *InvoiceInput) { in.CallbackURL = "/cb" }, want: []string{"CallbackURL"}},
+		{name: "negative expiry", modify: func(in *InvoiceInput) { in.ExpireMinutes = -1 }, want: []string{"ExpireMinutes"}},
+		{
+			name: "every field",
+			info: strict,
+			modify: func(in *InvoiceInput) {
+				*in = InvoiceInput{ExpireMinutes: -1}
+			},
+			want: []string{"Amount", "UID", "Phone", "CustomerID", "CallbackURL", "ExpireMinutes"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			if tt.modify != nil {
+				tt.modify(&input)
+			}
+			got := invalidFields(t, input.Validate(tt.info))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invalid fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveUID(t *testing.T) {
+	tests := []struct {
+		name       string
+		uid        string
+		paymentUID string
+		want       string
+		wantErr    bool
+	}{
+		{name: "UID", uid: "a", want: "a"},
+		{name: "PaymentUID", paymentUID: "a", want: "a"},
+		{name: "equal", uid: "a", paymentUID: "a", want: "a"},
+		{name: "neither"},
+		{name: "differ", uid: "a", paymentUID: "b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InvoiceInput{UID: tt.uid, PaymentUID: tt.paymentUID}.ResolveUID()
+			if tt.wantErr {
+				if fields := invalidFields(t, err); !reflect.DeepEqual(fields, []string{"PaymentUID"}) {
+					t.Fatalf("invalid fields = %v, want [PaymentUID]", fields)
+				}
+				return
+			}
+			if err != nil || got.UID != tt.want {
+				t.Errorf("ResolveUID = %q, %v, want %q", got.UID, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMongolianPhone(t *testing.T) {
+	tests := map[string]bool{
+		"99119911":     true,
+		"+97699119911": true,
+		"97699119911":  true,
+		"09119911":     false,
+		"9911991":      false,
+		"991199111":    false,
+		"9911991a":     false,
+		"+1 99119911":  false,
+		"":             false,
+	}
+	for phone, want := range tests {
+		if got := IsMongolianPhone(phone); got != want {
+			t.Errorf("IsMongolianPhone(%q) = %v, want %v", phone, got, want)
+		}
+	}
+}
+
+func TestAdapterConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config interface{ Validate() error }
+		want   []string
+	}{
+		{
+			name:   "qpay valid",
+			config: QpayAdapter{Username: "u", Password: "p", Endpoint: "https://merchant.qpay.mn/v2", Callback: "https://shop.mn/cb", InvoiceCode: "c", MerchantID: "m"},
+		},
+		{
+			name:   "qpay empty",
+			config: QpayAdapter{},
+			want:   []string{"Username", "Password", "Endpoint", "Callback", "InvoiceCode", "MerchantID"},
+		},
+		{
+			name:   "qpay display",
+			config: QpayAdapter{Username: "u", Password: "p", Endpoint: "https://merchant.qpay.mn/v2", Callback: "https://shop.mn/cb", InvoiceCode: "c", MerchantID: "m", LogoURL: "logo.png", MaxAmount: -1},
+			want:   []string{"LogoURL", "MaxAmount"},
+		},
+		{
+			name:   "socialpay blank secret",
+			config: SocialPayAdapter{Terminal: "t", Secret: "  ", Endpoint: "https://ecommerce.golomtbank.com"},
+			want:   []string{"Secret"},
+		},
+		{
+			name:   "pocket environment and terminal",
+			config: PocketAdapter{Merchant: "m", ClientID: "c", ClientSecret: "s", Environment: "staging"},
+			want:   []string{"Environment", "TerminalIDRaw"},
+		},
+		{
+			name:   "monpay without deeplink",
+			config: MonpayAdapter{Endpoint: "https://wallet.monpay.mn", Username: "u", AccountID: "a", Callback: "https://shop.mn/cb"},
+		},
+		{
+			name:   "monpay partial deeplink",
+			config: MonpayAdapter{Endpoint: "https://wallet.monpay.mn", Username: "u", AccountID: "a", Callback: "https://shop.mn/cb", ClientID: "c"},
+			want:   []string{"DeeplinkEndpoint", "ClientSecret", "GrantType", "RedirectURL"},
+		},
+		{
+			name:   "balc endpoint scheme",
+			config: BalcAdapter{Endpoint: "ftp://balc.mn", Token: "t"},
+			want:   []string{"Endpoint"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := invalidFields(t, tt.config.Validate())
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invalid fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// checkByBankInvoiceID lists the providers whose CheckInvoice takes the id
+// the provider assigned at creation rather than the merchant UID.
+var checkByBankInvoiceID = map[types.PaymentType]bool{
+	types.PaymentTypeQPay:     true,
+	types.PaymentTypeMonpay:   true,
+	types.PaymentTypeStorePay: true,
+}
+
+// StoreLookup returns a Lookup serving invoices recorded in st, e.g. the
+// Store of the SDK. The invoice is checked against the amount it was created
+// with; callbacks for invoices st does not know fail with ErrUnknownInvoice.
+func StoreLookup(st store.Store) Lookup {
+	return func(ctx context.Context, paymentType types.PaymentType, uid string) (types.CheckInvoiceInput, error) {
+		invoice, err := st.FindInvoice(ctx, paymentType, uid)
+		if errors.Is(err, store.ErrNotFound) {
+			return types.CheckInvoiceInput{}, fmt.Errorf("%w: %s %s", ErrUnknownInvoice, paymentType, uid)
+		}
+		if err != nil {
+			return types.CheckInvoiceInput{}, err
+		}
+
+		input := types.CheckInvoiceInput{Type: paymentType, UID: invoice.UID, Amount: invoice.Amount}
+		if checkByBankInvoiceID[paymentType] {
+			input.UID = invoice.BankInvoiceID
+		}
+		return input, nil
+	}
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+// Callback is what a Parser extracts from a provider request.
+type Callback struct {
+	UID      string            // invoice identifier as understood by CheckInvoice
+	Amount   types.Money       // amount reported by the provider, zero if absent; informational only
+	Verified bool              // payload signature was checked against the secret
+	Raw      map[string]string // callback fields as received
+}
+
+// Parser extracts a Callback from a provider request. It returns an error
+// wrapping ErrInvalidSignature when a signed payload does not verify.
+type Parser func(r *http.Request, body []byte) (*Callback, error)
+
+func defaultParsers(config sdk.Input) map[types.PaymentType]Parser {
+	return map[types.PaymentType]Parser{
+		// The QPay adapter registers its callback as <Callback>?uid=<UID>.
+		types.PaymentTypeQPay:     fieldParser("uid"),
+		types.PaymentTypeGolomt:   golomtParser(config.Golomt.Secret),
+		types.PaymentTypeSocial:   socialPayParser(config.SocialPay.Terminal, config.SocialPay.Secret),
+		types.PaymentTypeMonpay:   fieldParser("invoiceId", "uuid"), // deeplink invoices send invoiceId, branch QRs uuid
+		types.PaymentTypeSimple:   fieldParser("order_id"),
+		types.PaymentTypeTokipay:  fieldParser("requestId", "orderId", "uid"),
+		types.PaymentTypeStorePay: fieldParser("id", "loanId", "uid"),
+		types.PaymentTypePocket:   fieldParser("orderNumber", "order_number", "uid"),
+	}
+}
+
+// fieldParser reads the UID from the first of keys present in the query
+// string, form body or JSON body. The payload is unsigned; the receiver
+// re-checks the invoice with the provider before emitting an event.
+func fieldParser(keys ...string) Parser {
+	return func(r *http.Request, body []byte) (*Callback, error) {
+		values, err := callbackValues(r, body)
+		if err != nil {
+			return nil, err
+		}
+
+		callback := &Callback{Raw: values, Amount: parseAmount(values)}
+		for _, key := range keys {
+			if values[key] != "" {
+				callback.UID = values[key]
+				break
+			}
+		}
+		if callback.UID == "" {
+			return nil, fmt.Errorf("callback is missing %s", strings.Join(keys, " or "))
+		}
+		return callback, nil
+	}
+}
+
+// golomtParser verifies the push notification checksum:
+// HMAC-SHA256(secret, transactionId + errorCode + amount [+ token]).
+// Without a secret every callback is rejected.
+func golomtParser(secret string) Parser {
+	return func(r *http.Request, body []byte) (*Callback, error) {
+		if secret == "" {
+			return nil, fmt.Errorf("%w: golomt secret is not configured", ErrInvalidSignature)
+		}
+		values, err := callbackValues(r, body)
+		if err != nil {
+			return nil, err
+		}
+		if values["transactionId"] == "" {
+			return nil, errors.New("callback is missing transactionId")
+		}
+
+		message := values["transactionId"] + values["errorCode"] + values["amount"] + values["token"]
+		if !validHMAC(secret, message, values["checksum"]) {
+			return nil, ErrInvalidSignature
+		}
+		return &Callback{
+			UID:      values["transactionId"],
+			Amount:   parseAmount(values),
+			Verified: true,
+			Raw:      values,
+		}, nil
+	}
+}
+
+// socialPayParser verifies the invoice checksum:
+// HMAC-SHA256(secret, terminal + invoice + amount), as signed by the provider
+// for invoice requests, and rejects callbacks for another terminal. Without a
+// terminal and secret every callback is rejected.
+func socialPayParser(terminal, secret string) Parser {
+	return func(r *http.Request, body []byte) (*Callback, error) {
+		if terminal == "" || secret == "" {
+			return nil, fmt.Errorf("%w: socialpay terminal and secret are not configured", ErrInvalidSignature)
+		}
+		values, err := callbackValues(r, body)
+		if err != nil {
+			return nil, err
+		}
+		if values["invoice"] == "" {
+			return nil, errors.New("callback is missing invoice")
+		}
+		if values["terminal"] != "" && values["terminal"] != terminal {
+			return nil, fmt.Errorf("%w: unexpected terminal %s", ErrInvalidSignature, values["terminal"])
+		}
+		if !validHMAC(secret, terminal+values["invoice"]+values["amount"], values["checksum"]) {
+			return nil, ErrInvalidSignature
+		}
+		return &Callback{
+			UID:      values["invoice"],
+			Amount:   parseAmount(values),
+			Verified: true,
+			Raw:      values,
+		}, nil
+	}
+}
+
+func validHMAC(secret, message, checksum string) bool {
+	expected, err := hex.DecodeString(checksum)
+	if err != nil || checksum == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// callbackValues flattens the query string, a form body and a JSON object
+// body into one map; body fields win over query parameters.
+func callbackValues(r *http.Request, body []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	for key, vals := range r.URL.Query() {
+		values[key] = vals[0]
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return values, nil
+	}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid form body: %w", err)
+		}
+		for key, vals := range form {
+			values[key] = vals[0]
+		}
+		return values, nil
+	}
+
+	var object map[string]any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber() // keep amounts exactly as signed
+	if err := dec.Decode(&object); err != nil {
+		return nil, fmt.Errorf("invalid json body: %w", err)
+	}
+	for key, value := range object {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case bool:
+			values[key] = strconv.FormatBool(v)
+		default:
+			raw, _ := json.Marshal(v)
+			values[key] = string(raw)
+		}
+	}
+	return values, nil
+}
+
+// parseAmount reads the exact callback amount; the currency defaults to MNT.
+func parseAmount(values map[string]string) types.Money {
+	amount, err := types.ParseMoney(values["amount"], values["currency"])
+	if err != nil {
+		return types.Money{}
+	}
+	return amount
+}
@@ -0,0 +1,193 @@
+// Package webhook receives provider payment callbacks, verifies them where the
+// provider signs its payload, re-confirms the status through the SDK and
+// hands a normalized PaymentEvent to the application.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+const defaultMaxBodyBytes = 1 << 20
+
+// ErrInvalidSignature is returned by a Parser when a signed payload does not
+// match the configured secret, or when the secret to verify it is missing.
+var ErrInvalidSignature = errors.New("webhook: invalid callback signature")
+
+// ErrUnknownInvoice is returned by a Lookup for a callback UID that matches
+// no invoice of the application; the callback is answered with 404.
+var ErrUnknownInvoice = errors.New("webhook: unknown invoice")
+
+// PaymentEvent is emitted for every callback whose status was re-confirmed
+// with the provider.
+type PaymentEvent struct {
+	Type       types.PaymentType         `json:"type"`
+	UID        string                    `json:"uid"`
+	IsPaid     bool                      `json:"is_paid"`
+	Status     types.PaymentStatus       `json:"status"`
+	Amount     types.Money               `json:"amount"`   // amount the invoice was checked against, from Lookup
+	Verified   bool                      `json:"verified"` // callback signature was checked
+	Result     *types.CheckInvoiceResult `json:"result"`
+	Raw        map[string]string         `json:"raw"` // callback fields as received
+	ReceivedAt time.Time                 `json:"received_at"`
+}
+
+// EventHandler processes a confirmed event. Returning an error answers the
+// provider with 500 so that it retries the callback.
+type EventHandler func(ctx context.Context, event PaymentEvent) error
+
+// Lookup maps a callback UID to the input CheckInvoice expects, e.g. the
+// QPay bank invoice id and the expected amount of an order. Both must come
+// from the application's own records: callbacks are unsigned for most
+// providers, so nothing in them is trusted. A Lookup returns an error
+// wrapping ErrUnknownInvoice for UIDs it does not know.
+type Lookup func(ctx context.Context, paymentType types.PaymentType, uid string) (types.CheckInvoiceInput, error)
+
+type Input struct {
+	SDK     sdk.SDK
+	Config  sdk.Input // provider secrets used to verify signed callbacks
+	Handler EventHandler
+	// Lookup is required; StoreLookup serves it from a store.Store.
+	Lookup       Lookup
+	MaxBodyBytes int64 // defaults to 1 MiB
+}
+
+// Receiver is an http.Handler serving provider callbacks. Mounted as-is it
+// takes the provider from the last path segment (e.g. /callbacks/golomt);
+// For returns a handler bound to one provider.
+type Receiver struct {
+	input Input
+
+	mu      sync.RWMutex
+	parsers map[types.PaymentType]Parser
+}
+
+func New(input Input) (*Receiver, error) {
+	if input.SDK == nil {
+		return nil, errors.New("webhook: SDK is required")
+	}
+	if input.Handler == nil {
+		return nil, errors.New("webhook: Handler is required")
+	}
+	if input.Lookup == nil {
+		return nil, errors.New("webhook: Lookup is required")
+	}
+	if input.MaxBodyBytes <= 0 {
+		input.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	return &Receiver{
+		input:   input,
+		parsers: defaultParsers(input.Config),
+	}, nil
+}
+
+// Register adds or replaces the parser for paymentType, so callbacks of
+// custom providers can be received too.
+func (r *Receiver) Register(paymentType types.PaymentType, parser Parser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if parser == nil {
+		delete(r.parsers, paymentType)
+		return
+	}
+	r.parsers[paymentType] = parser
+}
+
+func (r *Receiver) parser(paymentType types.PaymentType) (Parser, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	parser, ok := r.parsers[paymentType]
+	return parser, ok
+}
+
+// For returns a handler that treats every request as a paymentType callback.
+func (r *Receiver) For(paymentType types.PaymentType) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.serve(w, req, paymentType)
+	})
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.serve(w, req, types.PaymentType(path.Base(req.URL.Path)))
+}
+
+func (r *Receiver) serve(w http.ResponseWriter, req *http.Request, paymentType types.PaymentType) {
+	parser, ok := r.parser(paymentType)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unsupported payment type: %s", paymentType), http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.input.MaxBodyBytes))
+	if err != nil {
+		http.Error(w, "unable to read callback body", http.StatusBadRequest)
+		return
+	}
+
+	callback, err := parser(req, body)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrInvalidSignature) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	event, err := r.confirm(req.Context(), paymentType, callback)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, ErrUnknownInvoice) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	if err := r.input.Handler(req.Context(), *event); err != nil {
+		http.Error(w, "callback handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, "OK")
+}
+
+// confirm re-checks the invoice with the provider; the callback alone is
+// never trusted to mark an invoice as paid. Only its UID is used, to find the
+// invoice and the amount it is expected to be paid with through Lookup.
+func (r *Receiver) confirm(ctx context.Context, paymentType types.PaymentType, callback *Callback) (*PaymentEvent, error) {
+	checkInput, err := r.input.Lookup(ctx, paymentType, callback.UID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s invoice %s: %w", paymentType, callback.UID, err)
+	}
+	checkInput.Type = paymentType
+
+	result, err := r.input.SDK.CheckContext(ctx, checkInput)
+	if err != nil {
+		return nil, fmt.Errorf("check %s invoice %s: %w", paymentType, checkInput.UID, err)
+	}
+
+	return &PaymentEvent{
+		Type:       paymentType,
+		UID:        callback.UID,
+		IsPaid:     result.IsPaid,
+		Status:     result.Status,
+		Amount:     checkInput.Amount,
+		Verified:   callback.Verified,
+		Result:     result,
+		Raw:        callback.Raw,
+		ReceivedAt: time.Now(),
+	}, nil
+}
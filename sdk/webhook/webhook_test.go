@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/techpartners-asia/payments-gateway/internal/fakeprovider"
+	"github.com/techpartners-asia/payments-gateway/sdk"
+	"github.com/techpartners-asia/payments-gateway/sdk/store"
+	"github.com/techpartners-asia/payments-gateway/sdk/types"
+)
+
+func sign(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSignedParsers(t *testing.T) {
+	const secret = "s3cret"
+	tests := []struct {
+		name         string
+		parser       Parser
+		query        string
+		wantUID      string
+		wantRejected bool
+	}{
+		{
+			name:    "golomt valid",
+			parser:  golomtParser(secret),
+			query:   "transactionId=order-1&errorCode=000&amount=1000&checksum=" + sign(secret, "order-1"+"000"+"1000"),
+			wantUID: "order-1",
+		},
+		{
+			name:         "golomt tampered amount",
+			parser:       golomtParser(secret),
+			query:        "transactionId=order-1&errorCode=000&amount=1&checksum=" + sign(secret, "order-1"+"000"+"1000"),
+			wantRejected: true,
+		},
+		{
+			name:         "golomt missing checksum",
+			parser:       golomtParser(secret),
+			query:        "transactionId=order-1&errorCode=000&amount=1000",
+			wantRejected: true,
+		},
+		{
+			name:         "golomt secret not configured",
+			parser:       golomtParser(""),
+			query:        "transactionId=order-1&errorCode=000&amount=1000&checksum=" + sign("", "order-1"+"000"+"1000"),
+			wantRejected: true,
+		},
+		{
+			name:    "socialpay valid",
+			parser:  socialPayParser("T1", secret),
+			query:   "invoice=order-1&amount=1000&terminal=T1&checksum=" + sign(secret, "T1"+"order-1"+"1000"),
+			wantUID: "order-1",
+		},
+		{
+			name:         "socialpay other terminal",
+			parser:       socialPayParser("T1", secret),
+			query:        "invoice=order-1&amount=1000&terminal=T2&checksum=" + sign(secret, "T2"+"order-1"+"1000"),
+			wantRejected: true,
+		},
+		{
+			name:         "socialpay wrong secret",
+			parser:       socialPayParser("T1", secret),
+			query:        "invoice=order-1&amount=1000&checksum=" + sign("other", "T1"+"order-1"+"1000"),
+			wantRejected: true,
+		},
+		{
+			name:         "socialpay not configured",
+			parser:       socialPayParser("", ""),
+			query:        "invoice=order-1&amount=1000&checksum=" + sign("", "order-1"+"1000"),
+			wantRejected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/callback?"+tt.query, nil)
+			callback, err := tt.parser(req, nil)
+			if tt.wantRejected {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Fatalf("err = %v, want ErrInvalidSignature", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if callback.UID != tt.wantUID || !callback.Verified {
+				t.Fatalf("callback = %+v, want verified UID %q", callback, tt.wantUID)
+			}
+		})
+	}
+}
+
+func TestNewRequiresLookup(t *testing.T) {
+	_, err := New(Input{
+		SDK:     sdk.NewSDK(sdk.Input{}),
+		Handler: func(context.Context, PaymentEvent) error { return nil },
+	})
+	if err == nil {
+		t.Fatal("New without Lookup succeeded")
+	}
+}
+
+func TestReceiverChecksStoredInvoice(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemory()
+	invoice := store.NewInvoice(
+		types.InvoiceInput{Type: types.PaymentTypeQPay, UID: "order-1", Amount: types.MNT(15000)},
+		&types.InvoiceResult{BankInvoiceID: "qpay-inv-1"},
+		time.Now(),
+	)
+	if err := st.SaveInvoice(ctx, invoice); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &fakeprovider.Provider{Answers: fakeprovider.Statuses(types.PaymentStatusPaid)}
+	var events []PaymentEvent
+	rc, err := New(Input{
+		SDK: sdk.NewSDK(sdk.Input{Providers: map[types.PaymentType]types.PaymentProvider{types.PaymentTypeQPay: provider}}),
+		Handler: func(ctx context.Context, event PaymentEvent) error {
+			events = append(events, event)
+			return nil
+		},
+		Lookup: StoreLookup(st),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		// The callback amount must not lower the amount the invoice is checked against.
+		{name: "known invoice", query: "uid=order-1&amount=1", wantStatus: http.StatusOK},
+		{name: "unknown invoice", query: "uid=order-2", wantStatus: http.StatusNotFound},
+		{name: "missing uid", query: "amount=15000", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider.Reset()
+			events = nil
+			w := httptest.NewRecorder()
+			rc.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/callbacks/qpay?"+tt.query, strings.NewReader("")))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(provider.Checks()) != 0 || len(events) != 0 {
+					t.Fatalf("rejected callback reached the provider or handler")
+				}
+				return
+			}
+			want := types.CheckInvoiceInput{Type: types.PaymentTypeQPay, UID: "qpay-inv-1", Amount: types.MNT(15000)}
+			if len(provider.Checks()) != 1 || provider.Checks()[0] != want {
+				t.Fatalf("checks = %+v, want [%+v]", provider.Checks(), want)
+			}
+			if len(events) != 1 || events[0].Amount != want.Amount {
+				t.Fatalf("events = %+v, want one with amount %s", events, want.Amount)
+			}
+		})
+	}
+}